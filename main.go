@@ -11,23 +11,31 @@ import (
 	"time"
 
 	"github.com/fatih/color"
-	"google.golang.org/genai"
 
 	"github.com/tstromberg/ucd/pkg/ucd"
+	"github.com/tstromberg/ucd/pkg/ucd/provider"
+	"github.com/tstromberg/ucd/pkg/ucd/sarif"
 )
 
 var (
-	versionA    string
-	versionB    string
-	diffFile    string
-	changesFile string
-	programName string
-	programDesc string
-	commitsFile string
-	apiKey      string
-	modelName   string
-	jsonOutput  bool
-	debugMode   bool
+	versionA        string
+	versionB        string
+	diffFile        string
+	changesFile     string
+	programName     string
+	programDesc     string
+	commitsFile     string
+	apiKey          string
+	modelName       string
+	aiBackend       string
+	aiBaseURL       string
+	aiResource      string
+	jsonOutput      bool
+	debugMode       bool
+	payloadJobs     int
+	verifyChangelog bool
+	sarifOutput     bool
+	issuesFormat    string
 )
 
 func init() {
@@ -36,23 +44,42 @@ func init() {
 	flag.StringVar(&diffFile, "diff", "", "File containing unified diff")
 	flag.StringVar(&commitsFile, "commit-messages", "", "File containing commit messages")
 	flag.StringVar(&changesFile, "changelog", "", "File containing changelog entries")
-	flag.StringVar(&apiKey, "api-key", "", "Google API key for Gemini")
+	flag.StringVar(&apiKey, "api-key", "", "API key for the selected AI backend")
 	flag.StringVar(&programName, "name", "", "name of program for context")
 	flag.StringVar(&programDesc, "description", "", "description of program for context")
 
-	flag.StringVar(&modelName, "model", "gemini-2.5-flash-preview-04-17", "Gemini model to use")
+	flag.StringVar(&modelName, "model", "gemini-2.5-flash-preview-04-17", "Model name to use")
+	flag.StringVar(&aiBackend, "ai-backend", "gemini", "AI backend: gemini, openai, azure-openai, anthropic, ollama, noop")
+	flag.StringVar(&aiBaseURL, "ai-base-url", "", "Override the AI backend's endpoint (Azure OpenAI, Ollama, OpenAI-compatible gateways)")
+	flag.StringVar(&aiResource, "ai-resource", "", "Azure OpenAI resource name")
 	flag.BoolVar(&jsonOutput, "json", false, "Output results in JSON format")
 	flag.BoolVar(&debugMode, "debug", false, "Enable debug output")
+	flag.IntVar(&payloadJobs, "jobs", 4, "Number of components to analyze in parallel (payload subcommand only)")
+	flag.BoolVar(&verifyChangelog, "verify-changelog", false, "Run deterministic changelog-completeness checks before analysis")
+	flag.BoolVar(&sarifOutput, "sarif", false, "Output results as a SARIF 2.1.0 log, for CI/code-scanning integration")
+	flag.StringVar(&issuesFormat, "issues", "", "Output per-file structured issues instead of a full report: \"json\" or \"csv\"")
 }
 
 func main() {
 	flag.Parse()
 
-	// Check for API key
-	if apiKey == "" {
-		apiKey = os.Getenv("GEMINI_API_KEY")
+	// Check for API key, unless the backend doesn't need one.
+	if apiKey == "" && aiBackend != string(provider.Noop) && aiBackend != string(provider.Ollama) {
+		envVar := apiKeyEnvVar(provider.Backend(aiBackend))
+		apiKey = os.Getenv(envVar)
 		if apiKey == "" {
-			log.Fatal("API key is required. Set it with -api-key flag or GEMINI_API_KEY environment variable.")
+			log.Fatalf("API key is required. Set it with -api-key flag or %s environment variable.", envVar)
+		}
+	}
+
+	if args := flag.Args(); len(args) > 0 {
+		switch args[0] {
+		case "payload":
+			runPayload(args)
+			return
+		case "npm", "pypi", "go", "oci":
+			runEcosystem(args)
+			return
 		}
 	}
 
@@ -61,6 +88,116 @@ func main() {
 	outputResult(result)
 }
 
+// backendAPIKeyEnvVar maps each AI backend to the environment variable
+// checked for an API key when -api-key is omitted.
+var backendAPIKeyEnvVar = map[provider.Backend]string{
+	provider.Gemini:      "GEMINI_API_KEY",
+	provider.OpenAI:      "OPENAI_API_KEY",
+	provider.AzureOpenAI: "AZURE_OPENAI_API_KEY",
+	provider.Anthropic:   "ANTHROPIC_API_KEY",
+}
+
+// apiKeyEnvVar returns the environment variable backend's API key should
+// be read from. Unknown backends fall back to GEMINI_API_KEY, matching
+// provider.New's own default-to-Gemini behavior for an empty/unset backend.
+func apiKeyEnvVar(backend provider.Backend) string {
+	if envVar, ok := backendAPIKeyEnvVar[backend]; ok {
+		return envVar
+	}
+	return "GEMINI_API_KEY"
+}
+
+// newProvider constructs the provider.Provider selected by -ai-backend.
+func newProvider(ctx context.Context) provider.Provider {
+	p, err := provider.New(ctx, provider.Backend(aiBackend), provider.Config{
+		APIKey:   apiKey,
+		BaseURL:  aiBaseURL,
+		Resource: aiResource,
+	})
+	if err != nil {
+		log.Fatalf("Error creating AI provider: %v", err)
+	}
+	return p
+}
+
+// runPayload handles the "payload" subcommand: analyzing every component
+// listed in a multi-repo release manifest.
+func runPayload(args []string) {
+	if len(args) < 2 {
+		log.Fatalf("syntax: ucd payload [manifest]")
+	}
+
+	manifestData, err := os.ReadFile(args[1])
+	if err != nil {
+		log.Fatalf("Error reading manifest: %v", err)
+	}
+
+	manifest, err := ucd.ParsePayloadManifest(manifestData)
+	if err != nil {
+		log.Fatalf("Error parsing manifest: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	p := newProvider(ctx)
+
+	result, err := ucd.CollectPayload(ctx, p, manifest, modelName, payloadJobs)
+	if err != nil {
+		log.Fatalf("Error analyzing payload: %v", err)
+	}
+
+	if jsonOutput {
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			log.Fatalf("Error marshaling to JSON: %v", err)
+		}
+		fmt.Println(string(jsonData))
+		return
+	}
+
+	outputPayloadText(result)
+}
+
+// runEcosystem handles the "npm", "pypi", "go", and "oci" subcommands:
+// collecting and analyzing a package or image directly from its
+// registry, rather than from a git repository or diff file.
+func runEcosystem(args []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	var data *ucd.AnalysisData
+	var err error
+
+	switch args[0] {
+	case "npm":
+		if len(args) < 4 {
+			log.Fatalf("syntax: ucd npm [package] [version-a] [version-b]")
+		}
+		data, err = ucd.CollectNpm(ctx, args[1], args[2], args[3])
+	case "pypi":
+		if len(args) < 4 {
+			log.Fatalf("syntax: ucd pypi [package] [version-a] [version-b]")
+		}
+		data, err = ucd.CollectPyPI(ctx, args[1], args[2], args[3])
+	case "go":
+		if len(args) < 4 {
+			log.Fatalf("syntax: ucd go [module] [version-a] [version-b]")
+		}
+		data, err = ucd.CollectGoModule(ctx, args[1], args[2], args[3])
+	case "oci":
+		if len(args) < 3 {
+			log.Fatalf("syntax: ucd oci [image-a] [image-b]")
+		}
+		data, err = ucd.CollectOCI(ctx, args[1], args[2])
+	}
+	if err != nil {
+		log.Fatalf("Error collecting %s data: %v", args[0], err)
+	}
+
+	outputResult(analyzeData(data))
+}
+
 // collectData gathers the required information for analysis
 func collectData() *ucd.AnalysisData {
 	args := flag.Args()
@@ -102,11 +239,12 @@ func collectFromGit(repoURL string) (*ucd.AnalysisData, error) {
 	}
 
 	config := ucd.Config{
-		RepoURL:     repoURL,
-		VersionA:    versionA,
-		VersionB:    versionB,
-		ProgramName: programName,
-		ProgramDesc: programDesc,
+		RepoURL:         repoURL,
+		VersionA:        versionA,
+		VersionB:        versionB,
+		ProgramName:     programName,
+		ProgramDesc:     programDesc,
+		VerifyChangelog: verifyChangelog,
 	}
 
 	return ucd.Collect(config)
@@ -150,23 +288,14 @@ func collectFromFiles(diffFile string) (*ucd.AnalysisData, error) {
 	}, nil
 }
 
-// analyzeData processes the collected data using the AI model
+// analyzeData processes the collected data using the selected AI backend.
 func analyzeData(data *ucd.AnalysisData) *ucd.Result {
-	// Set up AI client
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
 	defer cancel()
 
-	// Create client with API key using ClientConfig
-	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey:  apiKey,
-		Backend: genai.BackendGeminiAPI, // Explicitly set backend
-	})
-	if err != nil {
-		log.Fatalf("Error creating client: %v", err)
-	}
+	p := newProvider(ctx)
 
-	// Analyze the changes
-	result, err := ucd.AnalyzeChanges(ctx, client, data, modelName)
+	result, err := ucd.AnalyzeChanges(ctx, p, data, modelName)
 	if err != nil {
 		log.Fatalf("Error analyzing changes: %v", err)
 	}
@@ -176,13 +305,37 @@ func analyzeData(data *ucd.AnalysisData) *ucd.Result {
 
 // outputResult presents the analysis findings in the requested format
 func outputResult(result *ucd.Result) {
-	if jsonOutput {
+	switch {
+	case issuesFormat != "":
+		outputIssues(result)
+	case sarifOutput:
+		outputSARIF(result)
+	case jsonOutput:
 		outputJSON(result)
-	} else {
+	default:
 		outputText(result)
 	}
 }
 
+// outputIssues writes result's typed, per-file issues in the format
+// named by -issues ("json" or "csv").
+func outputIssues(result *ucd.Result) {
+	issues := result.Issues()
+
+	var err error
+	switch issuesFormat {
+	case "json":
+		err = ucd.WriteIssuesJSON(os.Stdout, issues)
+	case "csv":
+		err = ucd.WriteIssuesCSV(os.Stdout, issues)
+	default:
+		log.Fatalf("unknown -issues format %q: want \"json\" or \"csv\"", issuesFormat)
+	}
+	if err != nil {
+		log.Fatalf("Error writing issues: %v", err)
+	}
+}
+
 // outputJSON prints the result as formatted JSON.
 func outputJSON(result *ucd.Result) {
 	jsonData, err := json.MarshalIndent(result, "", "  ")
@@ -192,6 +345,16 @@ func outputJSON(result *ucd.Result) {
 	fmt.Println(string(jsonData))
 }
 
+// outputSARIF prints the result as a SARIF 2.1.0 log, for CI/code-scanning
+// integration.
+func outputSARIF(result *ucd.Result) {
+	jsonData, err := json.MarshalIndent(sarif.FromResult(result), "", "  ")
+	if err != nil {
+		log.Fatalf("Error marshaling SARIF: %v", err)
+	}
+	fmt.Println(string(jsonData))
+}
+
 func outputText(r *ucd.Result) {
 	title := color.New(color.FgHiBlue, color.Bold)
 	section := color.New(color.FgBlue, color.Bold)
@@ -221,14 +384,27 @@ func outputText(r *ucd.Result) {
 	}
 
 	// Header
-	if programName != "" {
-		title.Printf("\n📊 %s – Change Analysis Report\n", programName)
+	if r.Input.ProgramName != "" {
+		title.Printf("\n📊 %s – Change Analysis Report\n", r.Input.ProgramName)
 	} else {
 		title.Println("\n📊 Change Analysis Report")
 	}
-	fmt.Printf("   %s: %s → %s\n", r.Input.Source, versionA, versionB)
+	fmt.Printf("   %s: %s → %s\n", r.Input.Source, r.Input.VersionA, r.Input.VersionB)
 	fmt.Println(strings.Repeat("─", 80))
 
+	// Changelog gaps (deterministic pre-pass, see -verify-changelog)
+	if len(r.Input.Gaps) > 0 {
+		section.Printf("\n📋 Changelog Gaps (%d)\n\n", len(r.Input.Gaps))
+		for i, g := range r.Input.Gaps {
+			if g.Commit.SHA == "" {
+				fmt.Printf("%d. %s\n", i+1, g.Reason)
+				continue
+			}
+			fmt.Printf("%d. %s — %s\n", i+1, g.Commit, g.Reason)
+		}
+		fmt.Println(strings.Repeat("─", 80))
+	}
+
 	// Risk Assessment
 	if r.Summary != nil {
 		section.Println("\n🔍 Risk Assessment")
@@ -262,6 +438,43 @@ func outputText(r *ucd.Result) {
 	}
 }
 
+// outputPayloadText renders a multi-component payload result: one section
+// per component, using the same risk language as outputText, followed by a
+// roll-up table of components that reached High risk.
+func outputPayloadText(result *ucd.PayloadResult) {
+	title := color.New(color.FgHiBlue, color.Bold)
+	section := color.New(color.FgBlue, color.Bold)
+	errColor := color.New(color.FgHiRed)
+	critical := color.New(color.FgHiRed)
+
+	title.Println("\n📦 Release Payload – Change Analysis Report")
+	fmt.Printf("   %d component(s) analyzed\n", len(result.Components))
+	fmt.Println(strings.Repeat("═", 80))
+
+	for _, c := range result.Components {
+		section.Printf("\n▸ %s\n", c.Name)
+		if c.Error != "" {
+			errColor.Printf("   error: %s\n", c.Error)
+			continue
+		}
+		outputText(c.Result)
+	}
+
+	fmt.Println(strings.Repeat("═", 80))
+	high := result.HighRiskComponents()
+	if len(high) == 0 {
+		fmt.Println("\n✅ No components reached High risk.\n")
+		return
+	}
+
+	critical.Printf("\n‼️  Components with High risk (%d)\n\n", len(high))
+	for _, c := range high {
+		fmt.Printf("   %-30s malware_risk=%-2d silent_patch=%-2d\n",
+			c.Name, c.Result.Summary.MalwareRisk, c.Result.Summary.SilentPatch)
+	}
+	fmt.Println()
+}
+
 func wordwrap(text string, width int, indent ...string) string {
 	words := strings.Fields(text)
 	if len(words) == 0 {
@@ -0,0 +1,125 @@
+package ucd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Gap is a deterministic finding from VerifyChangelog: either a missing
+// version heading, or a commit that doesn't appear to be documented
+// anywhere in the changelog.
+type Gap struct {
+	Commit Commit `json:"commit,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// defaultBoringPatterns matches commit subjects that routinely go
+// undocumented without that being a problem: routine maintenance, merge
+// commits, and dependency bumps.
+var defaultBoringPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^(chore|test|ci|docs)(\(|:)`),
+	regexp.MustCompile(`^Merge `),
+	regexp.MustCompile(`^Bump `),
+}
+
+// isoDatePattern matches an ISO 8601 date (YYYY-MM-DD) anywhere in a string.
+var isoDatePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+
+// nonAlnumPattern matches runs of characters that aren't letters, digits,
+// or spaces, for normalizing commit subjects before substring comparison.
+var nonAlnumPattern = regexp.MustCompile(`[^a-z0-9 ]+`)
+
+// VerifyChangelog runs deterministic, offline checks against data's
+// changelog: whether it has a dated heading for VersionB, and whether
+// each non-boring commit in range is referenced by PR number or subject.
+// It's meant to run before the LLM pass, so the prompt can focus on
+// changes a fast, free check can't already explain.
+func VerifyChangelog(data *AnalysisData, boringPatterns ...*regexp.Regexp) []Gap {
+	if len(boringPatterns) == 0 {
+		boringPatterns = defaultBoringPatterns
+	}
+
+	var gaps []Gap
+	if !changelogHasVersionHeading(data.Changelog, data.VersionB) {
+		gaps = append(gaps, Gap{Reason: fmt.Sprintf("changelog has no dated heading for version %s", data.VersionB)})
+	}
+
+	for _, c := range data.Commits {
+		if isBoringCommit(c.Subject, boringPatterns) {
+			continue
+		}
+		if commitReferencedInChangelog(c, data.Changelog) {
+			continue
+		}
+		gaps = append(gaps, Gap{Commit: c, Reason: "not referenced in changelog by PR number or subject"})
+	}
+
+	return gaps
+}
+
+// changelogHasVersionHeading reports whether changelog contains a heading
+// line for versionB that also carries a dated entry, e.g. "## [v1.2.0] -
+// 2024-03-01".
+func changelogHasVersionHeading(changelog, versionB string) bool {
+	v := strings.TrimPrefix(versionB, "v")
+	headingPattern := regexp.MustCompile(fmt.Sprintf(`(?mi)^##?\s*\[?v?%s\]?.*$`, regexp.QuoteMeta(v)))
+
+	line := headingPattern.FindString(changelog)
+	if line == "" {
+		return false
+	}
+	return isoDatePattern.MatchString(line)
+}
+
+// isBoringCommit reports whether subject matches any of patterns.
+func isBoringCommit(subject string, patterns []*regexp.Regexp) bool {
+	for _, p := range patterns {
+		if p.MatchString(subject) {
+			return true
+		}
+	}
+	return false
+}
+
+// commitReferencedInChangelog reports whether c appears to be documented:
+// either its PR number is mentioned, or a normalized form of its subject
+// is a substring of the normalized changelog text.
+func commitReferencedInChangelog(c Commit, changelog string) bool {
+	if c.PRNumber != 0 && strings.Contains(changelog, fmt.Sprintf("#%d", c.PRNumber)) {
+		return true
+	}
+
+	subject := normalizeForMatch(c.Subject)
+	if subject == "" {
+		return false
+	}
+	return strings.Contains(normalizeForMatch(changelog), subject)
+}
+
+// normalizeForMatch lowercases s, strips punctuation, and collapses
+// whitespace, so minor formatting differences don't defeat the substring
+// match.
+func normalizeForMatch(s string) string {
+	s = nonAlnumPattern.ReplaceAllString(strings.ToLower(s), " ")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// GapSummary renders d.Gaps for the analysis prompt. It returns "" when
+// there are no gaps, so the prompt template can omit the section
+// entirely (e.g. VerifyChangelog was never run, or found nothing).
+func (d *AnalysisData) GapSummary() string {
+	if len(d.Gaps) == 0 {
+		return ""
+	}
+
+	var lines []string
+	for _, g := range d.Gaps {
+		if g.Commit.SHA == "" {
+			lines = append(lines, g.Reason)
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", g.Commit, g.Reason))
+	}
+	return strings.Join(lines, "\n")
+}
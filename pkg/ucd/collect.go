@@ -1,18 +1,25 @@
+// Package ucd provides tools to analyze differences between software versions
+// and identify undocumented changes.
 package ucd
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
+	"regexp"
 	"strings"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/tstromberg/ucd/pkg/ucd/deps"
+	"github.com/tstromberg/ucd/pkg/ucd/tracker"
 )
 
 // Config holds the configuration for code change collection.
 type Config struct {
 	// Data source configuration
 	RepoURL       string
+	Repo          *git.Repository // reuse an already-opened repository instead of cloning RepoURL
 	DiffPath      string
 	ChangelogPath string
 	CommitMsgs    string
@@ -22,18 +29,34 @@ type Config struct {
 	// Optional version identifiers
 	VersionA string
 	VersionB string
+
+	// Tracker enriches commits that reference an issue-tracker ID (e.g.
+	// "Bug 2145678:", "JIRA-123") with the linked issue's metadata.
+	Tracker         *tracker.Resolver
+	TrackerPatterns []*regexp.Regexp
+
+	// CheckOSV queries OSV.dev for each detected dependency bump to see
+	// whether it silently crosses a known vulnerability fix.
+	CheckOSV bool
+
+	// VerifyChangelog runs the deterministic changelog-completeness checks
+	// (see VerifyChangelog) and stores their findings in AnalysisData.Gaps.
+	VerifyChangelog bool
 }
 
 // AnalysisData contains collected code change information.
 type AnalysisData struct {
-	Source         string
-	Diff           string
-	CommitMessages string
-	Changelog      string
-	VersionA       string
-	VersionB       string
-	ProgramName    string
-	ProgramDesc    string
+	Source            string
+	Diff              string
+	CommitMessages    string
+	Commits           []Commit
+	DependencyChanges []deps.DependencyChange
+	Changelog         string
+	VersionA          string
+	VersionB          string
+	ProgramName       string
+	ProgramDesc       string
+	Gaps              []Gap
 }
 
 // Collect gathers all necessary data for analysis based on the provided config.
@@ -48,12 +71,16 @@ func Collect(cfg Config) (*AnalysisData, error) {
 
 	var (
 		diff, commitMsgs, changelog string
+		commits                     []Commit
 		err                         error
 	)
 
-	if cfg.RepoURL != "" {
+	if cfg.RepoURL != "" || cfg.Repo != nil {
 		// Git repository analysis mode
-		diff, commitMsgs, changelog, err = collectFromGit(cfg)
+		diff, commits, changelog, err = collectFromGit(cfg)
+		for _, c := range commits {
+			commitMsgs += c.Subject + "\n"
+		}
 	} else {
 		// Direct file analysis mode
 		diff, commitMsgs, changelog, err = collectFromFiles(cfg)
@@ -63,148 +90,120 @@ func Collect(cfg Config) (*AnalysisData, error) {
 		return nil, err
 	}
 
-	return &AnalysisData{
-		Diff:           diff,
-		CommitMessages: commitMsgs,
-		Changelog:      changelog,
-		VersionA:       cfg.VersionA,
-		VersionB:       cfg.VersionB,
-		Source:         cfg.RepoURL,
-		ProgramName:    cfg.ProgramName,
-		ProgramDesc:    cfg.ProgramDesc,
-	}, nil
-}
-
-// runCommand is a helper function that executes a command and returns its output with better error handling.
-func runCommand(dir string, name string, args ...string) (string, error) {
-	cmd := exec.Command(name, args...)
-	if dir != "" {
-		cmd.Dir = dir
+	depChanges := deps.DetectChanges(diff)
+	if cfg.CheckOSV {
+		flagSilentDependencyFixes(depChanges)
 	}
 
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	data := &AnalysisData{
+		Diff:              diff,
+		CommitMessages:    commitMsgs,
+		Commits:           commits,
+		DependencyChanges: depChanges,
+		Changelog:         changelog,
+		VersionA:          cfg.VersionA,
+		VersionB:          cfg.VersionB,
+		Source:            cfg.RepoURL,
+		ProgramName:       cfg.ProgramName,
+		ProgramDesc:       cfg.ProgramDesc,
+	}
 
-	err := cmd.Run()
-	if err != nil {
-		// Include stderr in error message for better debugging
-		return "", fmt.Errorf("%s command failed: %v\nStderr: %s", name, err, stderr.String())
+	if cfg.VerifyChangelog {
+		data.Gaps = VerifyChangelog(data)
 	}
 
-	return stdout.String(), nil
+	return data, nil
 }
 
-// collectFromGit extracts data from a Git repository.
-func collectFromGit(cfg Config) (diff, commitMsgs, changelog string, err error) {
-	// Check if git is available
-	if _, err := exec.LookPath("git"); err != nil {
-		return "", "", "", fmt.Errorf("git command not found: %v", err)
+// DependencySummary renders detected dependency bumps for the analysis
+// prompt. It returns "" when no manifest/lockfile changes were detected,
+// so the prompt template can omit the section entirely.
+func (d *AnalysisData) DependencySummary() string {
+	var lines []string
+	for _, c := range d.DependencyChanges {
+		line := fmt.Sprintf("%s %s %s -> %s (%s bump)", c.Ecosystem, c.Name, c.OldVersion, c.NewVersion, c.BumpKind)
+		if len(c.SilentVulnFixes) > 0 {
+			line += fmt.Sprintf(" [fixes %s]", strings.Join(c.SilentVulnFixes, ", "))
+		}
+		lines = append(lines, line)
 	}
+	return strings.Join(lines, "\n")
+}
 
-	// Create temporary directory
-	tempDir, err := os.MkdirTemp("", "ucd-git-*")
-	if err != nil {
-		return "", "", "", fmt.Errorf("failed to create temp directory: %w", err)
+// flagSilentDependencyFixes queries OSV.dev for each dependency bump and
+// records which known vulnerabilities it silently fixes. Lookup failures
+// are non-fatal: a bump simply isn't flagged.
+func flagSilentDependencyFixes(changes []deps.DependencyChange) {
+	ctx := context.Background()
+	for i := range changes {
+		fixed, err := deps.CheckSilentFix(ctx, changes[i])
+		if err != nil {
+			continue
+		}
+		changes[i].SilentVulnFixes = fixed
 	}
-	//	defer os.RemoveAll(tempDir)
+}
 
-	// Clone the repository
-	_, err = runCommand("", "git", "clone", "--quiet", cfg.RepoURL, tempDir)
-	if err != nil {
-		return "", "", "", err
+// collectFromGit extracts data from a Git repository, opening or cloning it
+// in-process via go-git rather than shelling out to the git binary. Each
+// commit in the returned range is parsed for PR/issue metadata and, if
+// cfg.Tracker is set, enriched with the linked tracker issue.
+func collectFromGit(cfg Config) (diff string, commits []Commit, changelog string, err error) {
+	repo := cfg.Repo
+	if repo == nil {
+		var tempDir string
+		repo, tempDir, err = openOrCloneRepo(cfg.RepoURL)
+		if err != nil {
+			return "", nil, "", err
+		}
+		defer removeAll(tempDir)
 	}
 
-	// Generate diff
-	diff, err = runCommand(tempDir, "git", "diff", cfg.VersionA, cfg.VersionB)
+	diff, err = diffBetweenRevisions(repo, cfg.VersionA, cfg.VersionB)
 	if err != nil {
-		return "", "", "", err
+		return "", nil, "", err
 	}
 
-	// Extract commit messages
-	commitMsgs, err = runCommand(tempDir, "git", "log", "--pretty=format:%s",
-		fmt.Sprintf("%s..%s", cfg.VersionA, cfg.VersionB))
+	rawCommits, err := commitsBetweenRevisions(repo, cfg.VersionA, cfg.VersionB)
 	if err != nil {
-		return "", "", "", err
+		return "", nil, "", err
+	}
+	commits = parseCommits(rawCommits, cfg.TrackerPatterns)
+	if cfg.Tracker != nil {
+		enrichCommits(commits, cfg.Tracker)
 	}
 
 	// Extract changelog (non-fatal if it fails)
-	changelog, _ = getChangelogFromGit(tempDir, cfg.VersionA, cfg.VersionB)
+	changelog, _ = getChangelogFromGit(repo, cfg.VersionA, cfg.VersionB)
 	if changelog == "" {
 		changelog = "No CHANGELOG found."
 	}
 
-	return diff, commitMsgs, changelog, nil
+	return diff, commits, changelog, nil
 }
 
-// getChangelogFromGit extracts changelog differences from a Git repository.
-func getChangelogFromGit(repoDir, versionA, versionB string) (string, error) {
-	// Find the first matching changelog file
-	var changelogFile string
-	for _, pattern := range []string{"CHANGELOG*", "changelog*", "CHANGES.md", "changes.md", "RELNOTES*"} {
-		if matches, _ := filepath.Glob(filepath.Join(repoDir, pattern)); len(matches) > 0 {
-			changelogFile = matches[0]
-			break
-		}
-	}
-
-	if changelogFile == "" {
+// getChangelogFromGit extracts added changelog lines between two revisions,
+// matching this entry point's long-standing output shape.
+func getChangelogFromGit(repo *git.Repository, versionA, versionB string) (string, error) {
+	relPath, err := findChangelogFile(repo, versionB)
+	if relPath == "" {
 		return "", fmt.Errorf("no changelog file found")
 	}
-
-	// Get relative path for git commands
-	relPath, err := filepath.Rel(repoDir, changelogFile)
 	if err != nil {
-		relPath = filepath.Base(changelogFile)
+		return "", err
 	}
 
-	// Get changelog contents at both versions
-	contentA, err := runCommand(repoDir, "git", "show", versionA+":"+relPath)
+	contentA, err := blobAtRevision(repo, versionA, relPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to get changelog at version %s: %w", versionA, err)
 	}
 
-	contentB, err := runCommand(repoDir, "git", "show", versionB+":"+relPath)
+	contentB, err := blobAtRevision(repo, versionB, relPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to get changelog at version %s: %w", versionB, err)
 	}
 
-	// Create temporary files for both versions
-	fileA, err := os.CreateTemp("", "changelog-*")
-	if err != nil {
-		return "", fmt.Errorf("failed to create temp file: %w", err)
-	}
-	defer os.Remove(fileA.Name())
-
-	fileB, err := os.CreateTemp("", "changelog-*")
-	if err != nil {
-		os.Remove(fileA.Name())
-		return "", fmt.Errorf("failed to create temp file: %w", err)
-	}
-	defer os.Remove(fileB.Name())
-
-	// Write contents to temporary files
-	if err := os.WriteFile(fileA.Name(), []byte(contentA), 0o644); err != nil {
-		return "", fmt.Errorf("failed to write temp file: %w", err)
-	}
-	if err := os.WriteFile(fileB.Name(), []byte(contentB), 0o644); err != nil {
-		return "", fmt.Errorf("failed to write temp file: %w", err)
-	}
-
-	// Run diff command on temp files
-	cmd := exec.Command("diff", "-u", "--label", versionA, "--label", versionB, fileA.Name(), fileB.Name())
-	out, _ := cmd.CombinedOutput()
-
-	// Extract only the lines starting with "+" and remove the "+" prefix
-	var newLines []string
-	for _, line := range strings.Split(string(out), "\n") {
-		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
-			// Skip the diff header line (starting with +++)
-			newLines = append(newLines, line[1:])
-		}
-	}
-
-	return strings.Join(newLines, "\n"), nil
+	return addedLines(contentA, contentB), nil
 }
 
 // collectFromFiles extracts data from provided files.
@@ -0,0 +1,145 @@
+package ucd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// commitSig is a fixed author/time so commits made across tests don't
+// depend on wall-clock time.
+var commitSig = &object.Signature{Name: "ucd-test", Email: "ucd-test@example.com", When: time.Unix(1700000000, 0)}
+
+// newMemRepo creates an in-memory git repository, so collectFromGit and
+// getChangelogFromGit can be exercised without touching disk.
+func newMemRepo(t *testing.T) *git.Repository {
+	t.Helper()
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatalf("init in-memory repo: %v", err)
+	}
+	return repo
+}
+
+// writeAndCommit writes files to repo's worktree and commits them,
+// returning the new commit's hash.
+func writeAndCommit(t *testing.T, repo *git.Repository, files map[string]string, message string) plumbing.Hash {
+	t.Helper()
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+
+	for path, content := range files {
+		f, err := wt.Filesystem.Create(path)
+		if err != nil {
+			t.Fatalf("create %s: %v", path, err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+		f.Close()
+		if _, err := wt.Add(path); err != nil {
+			t.Fatalf("add %s: %v", path, err)
+		}
+	}
+
+	hash, err := wt.Commit(message, &git.CommitOptions{Author: commitSig})
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	return hash
+}
+
+// tagCommit tags hash as name.
+func tagCommit(t *testing.T, repo *git.Repository, name string, hash plumbing.Hash) {
+	t.Helper()
+	if _, err := repo.CreateTag(name, hash, nil); err != nil {
+		t.Fatalf("tag %s: %v", name, err)
+	}
+}
+
+func TestCollectFromGit(t *testing.T) {
+	t.Run("basic diff and commits", func(t *testing.T) {
+		repo := newMemRepo(t)
+		v1 := writeAndCommit(t, repo, map[string]string{"main.go": "package main\n"}, "initial commit")
+		tagCommit(t, repo, "v1", v1)
+		v2 := writeAndCommit(t, repo, map[string]string{"main.go": "package main\n\nfunc main() {}\n"}, "add main func")
+		tagCommit(t, repo, "v2", v2)
+
+		diff, commits, changelog, err := collectFromGit(Config{Repo: repo, VersionA: "v1", VersionB: "v2"})
+		if err != nil {
+			t.Fatalf("collectFromGit: %v", err)
+		}
+		if !strings.Contains(diff, "func main()") {
+			t.Errorf("diff = %q, want it to contain the added line", diff)
+		}
+		if len(commits) != 1 || commits[0].Subject != "add main func" {
+			t.Errorf("commits = %+v, want exactly one commit titled %q", commits, "add main func")
+		}
+		if changelog != "No CHANGELOG found." {
+			t.Errorf("changelog = %q, want the no-changelog placeholder", changelog)
+		}
+	})
+
+	t.Run("empty diff between identical revisions", func(t *testing.T) {
+		repo := newMemRepo(t)
+		v1 := writeAndCommit(t, repo, map[string]string{"main.go": "package main\n"}, "initial commit")
+		tagCommit(t, repo, "v1", v1)
+
+		diff, commits, _, err := collectFromGit(Config{Repo: repo, VersionA: "v1", VersionB: "v1"})
+		if err != nil {
+			t.Fatalf("collectFromGit: %v", err)
+		}
+		if diff != "" {
+			t.Errorf("diff = %q, want an empty diff between a revision and itself", diff)
+		}
+		if len(commits) != 0 {
+			t.Errorf("commits = %+v, want none between a revision and itself", commits)
+		}
+	})
+
+	t.Run("tag not found", func(t *testing.T) {
+		repo := newMemRepo(t)
+		v1 := writeAndCommit(t, repo, map[string]string{"main.go": "package main\n"}, "initial commit")
+		tagCommit(t, repo, "v1", v1)
+
+		if _, _, _, err := collectFromGit(Config{Repo: repo, VersionA: "v1", VersionB: "does-not-exist"}); err == nil {
+			t.Fatal("collectFromGit with an unresolvable tag: want error, got nil")
+		}
+	})
+}
+
+func TestGetChangelogFromGit(t *testing.T) {
+	t.Run("added lines", func(t *testing.T) {
+		repo := newMemRepo(t)
+		v1 := writeAndCommit(t, repo, map[string]string{"CHANGELOG.md": "# Changelog\n\n## v1\n- initial release\n"}, "v1")
+		tagCommit(t, repo, "v1", v1)
+		v2 := writeAndCommit(t, repo, map[string]string{"CHANGELOG.md": "# Changelog\n\n## v2\n- new feature\n\n## v1\n- initial release\n"}, "v2")
+		tagCommit(t, repo, "v2", v2)
+
+		got, err := getChangelogFromGit(repo, "v1", "v2")
+		if err != nil {
+			t.Fatalf("getChangelogFromGit: %v", err)
+		}
+		if got != "## v2\n- new feature\n" {
+			t.Errorf("getChangelogFromGit = %q, want clean, line-anchored added lines", got)
+		}
+	})
+
+	t.Run("no changelog file", func(t *testing.T) {
+		repo := newMemRepo(t)
+		v1 := writeAndCommit(t, repo, map[string]string{"main.go": "package main\n"}, "initial commit")
+		tagCommit(t, repo, "v1", v1)
+
+		if _, err := getChangelogFromGit(repo, "v1", "v1"); err == nil {
+			t.Fatal("getChangelogFromGit with no CHANGELOG file: want error, got nil")
+		}
+	})
+}
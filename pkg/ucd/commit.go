@@ -0,0 +1,174 @@
+package ucd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/tstromberg/ucd/pkg/ucd/tracker"
+)
+
+// Commit pairs a single commit with metadata parsed out of its message:
+// the PR it was merged through, any issues it closes, and any
+// issue-tracker IDs it references (e.g. "Bug 2145678", "JIRA-123"),
+// optionally enriched with what the tracker knows about each ID.
+type Commit struct {
+	SHA        string
+	Subject    string
+	PRNumber   int              `json:"pr_number,omitempty"`
+	Fixes      []int            `json:"fixes,omitempty"`
+	TrackerIDs []string         `json:"tracker_ids,omitempty"`
+	Issues     []*tracker.Issue `json:"issues,omitempty"`
+}
+
+// mergePRPattern matches GitHub's default merge-commit subject.
+var mergePRPattern = regexp.MustCompile(`Merge pull request #(\d+)`)
+
+// fixesPattern matches "Fixes #N" / "Closes #N" trailers, case-insensitive.
+var fixesPattern = regexp.MustCompile(`(?i)\b(?:fixes|closes) #(\d+)`)
+
+// defaultTrackerPatterns matches common issue-tracker ID conventions when
+// no custom pattern is configured. Each capture group is the ID as
+// splitTrackerPrefix expects to receive it.
+var defaultTrackerPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\b(Bug \d+):`),
+	regexp.MustCompile(`\b([A-Z][A-Z0-9]+-\d+)\b`), // JIRA-123 style
+}
+
+// splitTrackerPrefix splits a tracker ID like "Bug 2145678" or "JIRA-123"
+// into the prefix a Resolver is registered under ("Bug", "JIRA") and the
+// ID to hand to that tracker's Client.
+func splitTrackerPrefix(id string) (prefix, trackerID string) {
+	if i := strings.IndexByte(id, ' '); i > 0 {
+		return id[:i], id[i+1:]
+	}
+	if i := strings.IndexByte(id, '-'); i > 0 {
+		return id[:i], id
+	}
+	return id, id
+}
+
+// ParseCommit extracts PR/issue metadata from a commit's SHA and full
+// message (subject + body). trackerPatterns, if non-empty, replaces
+// defaultTrackerPatterns for recognizing issue-tracker IDs; each pattern
+// must have exactly one capture group containing the bare ID.
+func ParseCommit(sha, message string, trackerPatterns ...*regexp.Regexp) Commit {
+	c := Commit{
+		SHA:     sha,
+		Subject: strings.SplitN(message, "\n", 2)[0],
+	}
+
+	if m := mergePRPattern.FindStringSubmatch(message); m != nil {
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			c.PRNumber = n
+		}
+	}
+
+	for _, m := range fixesPattern.FindAllStringSubmatch(message, -1) {
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			c.Fixes = append(c.Fixes, n)
+		}
+	}
+
+	patterns := trackerPatterns
+	if len(patterns) == 0 {
+		patterns = defaultTrackerPatterns
+	}
+	for _, p := range patterns {
+		for _, m := range p.FindAllStringSubmatch(message, -1) {
+			c.TrackerIDs = append(c.TrackerIDs, m[1])
+		}
+	}
+
+	return c
+}
+
+// parseCommits parses PR/issue metadata out of each raw commit's message.
+func parseCommits(raw []*object.Commit, trackerPatterns []*regexp.Regexp) []Commit {
+	commits := make([]Commit, 0, len(raw))
+	for _, c := range raw {
+		commits = append(commits, ParseCommit(c.Hash.String(), c.Message, trackerPatterns...))
+	}
+	return commits
+}
+
+// enrichCommits resolves every tracker ID on each commit against resolver,
+// skipping (non-fatally) IDs with no registered client or that fail to
+// resolve.
+func enrichCommits(commits []Commit, resolver *tracker.Resolver) {
+	ctx := context.Background()
+	for i := range commits {
+		for _, id := range commits[i].TrackerIDs {
+			prefix, trackerID := splitTrackerPrefix(id)
+			issue, err := resolver.Resolve(ctx, prefix, trackerID)
+			if err != nil {
+				continue
+			}
+			commits[i].Issues = append(commits[i].Issues, issue)
+		}
+	}
+}
+
+// String renders a Commit the way report text should reference it, e.g.
+// "abc1234 (PR #4421, Bug 2145678) Bug 2145678: fix parser overflow".
+func (c Commit) String() string {
+	var extra []string
+	if c.PRNumber != 0 {
+		extra = append(extra, fmt.Sprintf("PR #%d", c.PRNumber))
+	}
+	for _, id := range c.TrackerIDs {
+		extra = append(extra, id)
+	}
+	if len(extra) == 0 {
+		return fmt.Sprintf("%s %s", c.SHA, c.Subject)
+	}
+	return fmt.Sprintf("%s (%s) %s", c.SHA, strings.Join(extra, ", "), c.Subject)
+}
+
+// undocumentedSecurityIssue reports whether this commit links to a tracker
+// issue flagged as security-sensitive that its own subject doesn't
+// acknowledge, and if so, returns that issue.
+func (c Commit) undocumentedSecurityIssue() *tracker.Issue {
+	subject := strings.ToLower(c.Subject)
+	for _, issue := range c.Issues {
+		if issue.SecurityFlag && !strings.Contains(subject, "security") && !strings.Contains(subject, "cve") {
+			return issue
+		}
+	}
+	return nil
+}
+
+// CommitSummary renders enriched commit metadata for the analysis prompt.
+// It returns "" when no commit has PR, Fixes, or tracker metadata worth
+// calling out, so the prompt template can omit the section entirely.
+func (d *AnalysisData) CommitSummary() string {
+	var lines []string
+	for _, c := range d.Commits {
+		if c.PRNumber == 0 && len(c.Fixes) == 0 && len(c.TrackerIDs) == 0 {
+			continue
+		}
+
+		line := c.SHA
+		if c.PRNumber != 0 {
+			line += fmt.Sprintf(" (PR #%d)", c.PRNumber)
+		}
+		for _, n := range c.Fixes {
+			line += fmt.Sprintf(" fixes #%d", n)
+		}
+		for _, issue := range c.Issues {
+			security := ""
+			if issue.SecurityFlag {
+				security = ", security"
+			}
+			line += fmt.Sprintf(" %s: %q%s", issue.ID, issue.Title, security)
+		}
+		line += ": " + c.Subject
+
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
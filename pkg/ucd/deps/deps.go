@@ -0,0 +1,174 @@
+// Package deps recognizes ecosystem dependency-manifest files inside a
+// unified diff and extracts the version bumps they describe, so the
+// analyzer can reason about lockfile churn instead of seeing opaque line
+// changes.
+package deps
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// Ecosystem identifies the package manager a manifest file belongs to.
+type Ecosystem string
+
+const (
+	Go      Ecosystem = "go"
+	NPM     Ecosystem = "npm"
+	PyPI    Ecosystem = "pypi"
+	Cargo   Ecosystem = "cargo"
+	Maven   Ecosystem = "maven"
+	Unknown Ecosystem = "unknown"
+)
+
+// BumpKind classifies how a version changed.
+type BumpKind string
+
+const (
+	BumpMajor     BumpKind = "major"
+	BumpMinor     BumpKind = "minor"
+	BumpPatch     BumpKind = "patch"
+	BumpDowngrade BumpKind = "downgrade"
+	BumpUnknown   BumpKind = "unknown"
+)
+
+// DependencyChange describes a single dependency's version bump, as seen
+// in a diff hunk touching a manifest or lockfile.
+type DependencyChange struct {
+	Name       string    `json:"name"`
+	Ecosystem  Ecosystem `json:"ecosystem"`
+	OldVersion string    `json:"old_version"`
+	NewVersion string    `json:"new_version"`
+	BumpKind   BumpKind  `json:"bump_kind"`
+
+	// SilentVulnFixes holds the OSV.dev IDs of vulnerabilities this bump
+	// fixes, populated by CheckSilentFix when Config.CheckOSV is set.
+	SilentVulnFixes []string `json:"silent_vuln_fixes,omitempty"`
+}
+
+// manifestPatterns maps a filename to the ecosystem it belongs to.
+var manifestPatterns = map[string]Ecosystem{
+	"go.mod":            Go,
+	"go.sum":            Go,
+	"package.json":      NPM,
+	"package-lock.json": NPM,
+	"requirements.txt":  PyPI,
+	"Cargo.toml":        Cargo,
+	"Cargo.lock":        Cargo,
+	"pom.xml":           Maven,
+}
+
+// IsManifest reports whether path is a recognized dependency manifest or
+// lockfile, and if so, which ecosystem it belongs to.
+func IsManifest(path string) (Ecosystem, bool) {
+	eco, ok := manifestPatterns[filepath.Base(path)]
+	return eco, ok
+}
+
+// diffFileHeaderPattern matches unified diff file headers, e.g.
+// "diff --git a/go.mod b/go.mod".
+var diffFileHeaderPattern = regexp.MustCompile(`(?m)^diff --git a/(\S+) b/(\S+)$`)
+
+// DetectChanges scans a unified diff for hunks touching recognized
+// manifest files and extracts the dependency version bumps within them.
+func DetectChanges(diff string) []DependencyChange {
+	var changes []DependencyChange
+
+	for _, section := range splitByFile(diff) {
+		eco, ok := IsManifest(section.path)
+		if !ok {
+			continue
+		}
+
+		var parsed []DependencyChange
+		switch eco {
+		case Go:
+			parsed = parseGoMod(section.body)
+		case NPM:
+			parsed = parseNPM(section.body)
+		case PyPI:
+			parsed = parsePyPI(section.body)
+		case Cargo:
+			parsed = parseCargo(section.body)
+		case Maven:
+			parsed = parseMaven(section.body)
+		}
+
+		for i := range parsed {
+			parsed[i].Ecosystem = eco
+			parsed[i].BumpKind = classifyBump(parsed[i].OldVersion, parsed[i].NewVersion)
+		}
+		changes = append(changes, parsed...)
+	}
+
+	return changes
+}
+
+// fileSection is one file's hunk body within a multi-file unified diff.
+type fileSection struct {
+	path string
+	body string
+}
+
+// splitByFile breaks a unified diff into per-file sections using "diff
+// --git" headers.
+func splitByFile(diff string) []fileSection {
+	matches := diffFileHeaderPattern.FindAllStringSubmatchIndex(diff, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	var sections []fileSection
+	for i, m := range matches {
+		start := m[1]
+		end := len(diff)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		path := diff[m[4]:m[5]] // "b/" capture group
+		sections = append(sections, fileSection{path: path, body: diff[start:end]})
+	}
+	return sections
+}
+
+// classifyBump compares two semver-ish version strings and classifies the
+// bump. If either version doesn't parse as semver, or the two are equal,
+// the result is BumpUnknown.
+func classifyBump(oldV, newV string) BumpKind {
+	oldSV, newSV := normalizeSemver(oldV), normalizeSemver(newV)
+	if !semver.IsValid(oldSV) || !semver.IsValid(newSV) {
+		return BumpUnknown
+	}
+
+	switch semver.Compare(oldSV, newSV) {
+	case 0:
+		return BumpUnknown
+	case 1:
+		return BumpDowngrade
+	}
+
+	oldMajor, newMajor := semver.Major(oldSV), semver.Major(newSV)
+	if oldMajor != newMajor {
+		return BumpMajor
+	}
+	if semver.MajorMinor(oldSV) != semver.MajorMinor(newSV) {
+		return BumpMinor
+	}
+	return BumpPatch
+}
+
+// normalizeSemver prefixes a bare "1.2.3" version with "v" so it parses
+// with golang.org/x/mod/semver, which requires the "v" prefix.
+func normalizeSemver(v string) string {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return v
+	}
+	if !strings.HasPrefix(v, "v") {
+		return "v" + v
+	}
+	return v
+}
@@ -0,0 +1,112 @@
+package deps
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// osvQueryURL is OSV.dev's batch-free single-package query endpoint.
+const osvQueryURL = "https://api.osv.dev/v1/query"
+
+// osvEcosystem maps this package's Ecosystem to the name OSV.dev expects.
+var osvEcosystem = map[Ecosystem]string{
+	Go:    "Go",
+	NPM:   "npm",
+	PyPI:  "PyPI",
+	Cargo: "crates.io",
+	Maven: "Maven",
+}
+
+type osvQueryRequest struct {
+	Version string `json:"version"`
+	Package struct {
+		Name      string `json:"name"`
+		Ecosystem string `json:"ecosystem"`
+	} `json:"package"`
+}
+
+type osvQueryResponse struct {
+	Vulns []struct {
+		ID      string `json:"id"`
+		Summary string `json:"summary"`
+	} `json:"vulns"`
+}
+
+// CheckSilentFix queries OSV.dev to determine whether any known
+// vulnerability affects c.OldVersion but not c.NewVersion, i.e. whether
+// this bump silently crosses a security fix. It returns the IDs of any
+// such vulnerabilities.
+func CheckSilentFix(ctx context.Context, c DependencyChange) ([]string, error) {
+	ecosystem, ok := osvEcosystem[c.Ecosystem]
+	if !ok {
+		return nil, fmt.Errorf("unsupported ecosystem for OSV: %s", c.Ecosystem)
+	}
+
+	affectedOld, err := osvVulnsForVersion(ctx, ecosystem, c.Name, c.OldVersion)
+	if err != nil {
+		return nil, fmt.Errorf("query old version: %w", err)
+	}
+	if len(affectedOld) == 0 {
+		return nil, nil
+	}
+
+	affectedNew, err := osvVulnsForVersion(ctx, ecosystem, c.Name, c.NewVersion)
+	if err != nil {
+		return nil, fmt.Errorf("query new version: %w", err)
+	}
+	stillAffected := make(map[string]bool, len(affectedNew))
+	for _, id := range affectedNew {
+		stillAffected[id] = true
+	}
+
+	var fixed []string
+	for _, id := range affectedOld {
+		if !stillAffected[id] {
+			fixed = append(fixed, id)
+		}
+	}
+	return fixed, nil
+}
+
+// osvVulnsForVersion returns the vulnerability IDs OSV.dev reports as
+// affecting name@version in ecosystem.
+func osvVulnsForVersion(ctx context.Context, ecosystem, name, version string) ([]string, error) {
+	reqBody := osvQueryRequest{Version: version}
+	reqBody.Package.Name = name
+	reqBody.Package.Ecosystem = ecosystem
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, osvQueryURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("query osv.dev: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("osv.dev returned %s", resp.Status)
+	}
+
+	var parsed osvQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("decode osv.dev response: %w", err)
+	}
+
+	ids := make([]string, 0, len(parsed.Vulns))
+	for _, v := range parsed.Vulns {
+		ids = append(ids, v.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,90 @@
+package deps
+
+import "regexp"
+
+// goModLinePattern matches a go.mod require line, with or without the
+// leading "require" keyword (block form vs single-line form).
+var goModLinePattern = regexp.MustCompile(`(?m)^[-+]\s*(?:require\s+)?([\w./-]+\.[\w./-]+)\s+(v[\w.+-]+)`)
+
+// parseGoMod pairs removed/added go.mod require versions by module path.
+func parseGoMod(hunk string) []DependencyChange {
+	return pairDiffMatches(hunk, goModLinePattern)
+}
+
+// npmLinePattern matches a package.json/package-lock.json dependency
+// line: "name": "^1.2.3".
+var npmLinePattern = regexp.MustCompile(`(?m)^[-+]\s*"([^"]+)":\s*"[~^]?([\w.-]+)"`)
+
+// parseNPM pairs removed/added npm dependency versions by package name.
+func parseNPM(hunk string) []DependencyChange {
+	return pairDiffMatches(hunk, npmLinePattern)
+}
+
+// pypiLinePattern matches a requirements.txt pin: name==1.2.3.
+var pypiLinePattern = regexp.MustCompile(`(?m)^[-+]\s*([\w.-]+)\s*==\s*([\w.-]+)`)
+
+// parsePyPI pairs removed/added requirements.txt pins by package name.
+func parsePyPI(hunk string) []DependencyChange {
+	return pairDiffMatches(hunk, pypiLinePattern)
+}
+
+// cargoLinePattern matches a Cargo.toml/Cargo.lock dependency assignment:
+// name = "1.2.3".
+var cargoLinePattern = regexp.MustCompile(`(?m)^[-+]\s*([\w.-]+)\s*=\s*"([\w.-]+)"`)
+
+// parseCargo pairs removed/added Cargo dependency versions by crate name.
+func parseCargo(hunk string) []DependencyChange {
+	return pairDiffMatches(hunk, cargoLinePattern)
+}
+
+// mavenVersionPattern matches a pom.xml <version> element on its own line.
+var mavenVersionPattern = regexp.MustCompile(`(?m)^[-+]\s*<version>([\w.-]+)</version>`)
+
+// parseMaven pairs removed/added pom.xml <version> elements positionally,
+// since XML doesn't carry the dependency name on the same line.
+func parseMaven(hunk string) []DependencyChange {
+	var removed, added []string
+	for _, m := range mavenVersionPattern.FindAllStringSubmatch(hunk, -1) {
+		line := m[0]
+		if line[0] == '-' {
+			removed = append(removed, m[1])
+		} else {
+			added = append(added, m[1])
+		}
+	}
+
+	var changes []DependencyChange
+	for i := 0; i < len(removed) && i < len(added); i++ {
+		if removed[i] == added[i] {
+			continue
+		}
+		changes = append(changes, DependencyChange{Name: "(pom.xml dependency)", OldVersion: removed[i], NewVersion: added[i]})
+	}
+	return changes
+}
+
+// pairDiffMatches runs pattern over hunk and pairs each name's removed
+// ("-") version with its added ("+") version.
+func pairDiffMatches(hunk string, pattern *regexp.Regexp) []DependencyChange {
+	removed := map[string]string{}
+	added := map[string]string{}
+
+	for _, m := range pattern.FindAllStringSubmatch(hunk, -1) {
+		sign, name, version := m[0][0], m[1], m[2]
+		if sign == '-' {
+			removed[name] = version
+		} else {
+			added[name] = version
+		}
+	}
+
+	var changes []DependencyChange
+	for name, oldVersion := range removed {
+		newVersion, ok := added[name]
+		if !ok || newVersion == oldVersion {
+			continue
+		}
+		changes = append(changes, DependencyChange{Name: name, OldVersion: oldVersion, NewVersion: newVersion})
+	}
+	return changes
+}
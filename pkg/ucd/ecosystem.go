@@ -0,0 +1,83 @@
+package ucd
+
+import (
+	"context"
+
+	"github.com/tstromberg/ucd/pkg/ucd/deps"
+	"github.com/tstromberg/ucd/pkg/ucd/registry"
+)
+
+// CollectNpm, CollectPyPI, CollectGoModule, and CollectOCI are the
+// ecosystem-aware counterparts to Collect: instead of walking a git
+// repository, they pull both versions directly from the package's
+// registry (see pkg/ucd/registry) and build an AnalysisData from the
+// resulting diff.
+//
+// These are free functions rather than options on a stateful coordinator:
+// the package's prior Service/AIAnalyzer lineage (pre-dating the provider
+// abstraction) has since been removed as dead code, and main.go's npm/pypi/
+// go/oci subcommands call straight through to AnalyzeChanges the same way
+// the git and diff-file subcommands call Collect directly.
+
+// CollectNpm collects an AnalysisData comparing versionA and versionB
+// of the npm package name.
+func CollectNpm(ctx context.Context, name, versionA, versionB string) (*AnalysisData, error) {
+	res, err := registry.FetchNpm(ctx, name, versionA, versionB)
+	if err != nil {
+		return nil, err
+	}
+	return analysisDataFromFetch(res, name, versionA, versionB), nil
+}
+
+// CollectPyPI collects an AnalysisData comparing versionA and versionB
+// of the PyPI package name.
+func CollectPyPI(ctx context.Context, name, versionA, versionB string) (*AnalysisData, error) {
+	res, err := registry.FetchPyPI(ctx, name, versionA, versionB)
+	if err != nil {
+		return nil, err
+	}
+	return analysisDataFromFetch(res, name, versionA, versionB), nil
+}
+
+// CollectGoModule collects an AnalysisData comparing versionA and
+// versionB of the Go module path.
+func CollectGoModule(ctx context.Context, module, versionA, versionB string) (*AnalysisData, error) {
+	res, err := registry.FetchGoModule(ctx, module, versionA, versionB)
+	if err != nil {
+		return nil, err
+	}
+	return analysisDataFromFetch(res, module, versionA, versionB), nil
+}
+
+// CollectOCI collects an AnalysisData comparing two container images.
+// Unlike the other ecosystem collectors, an OCI comparison has no
+// single package name: refA and refB are full image references (e.g.
+// "ghcr.io/org/img:1.0" and "ghcr.io/org/img:1.1").
+func CollectOCI(ctx context.Context, refA, refB string) (*AnalysisData, error) {
+	res, err := registry.FetchOCI(ctx, refA, refB)
+	if err != nil {
+		return nil, err
+	}
+	return analysisDataFromFetch(res, refA, refA, refB), nil
+}
+
+// analysisDataFromFetch builds an AnalysisData from a registry fetch
+// result, running the same dependency-change detection Collect does on
+// the resulting diff.
+func analysisDataFromFetch(res *registry.FetchResult, name, versionA, versionB string) *AnalysisData {
+	source := res.RepoURL
+	if source == "" {
+		source = name
+	}
+
+	return &AnalysisData{
+		Source:            source,
+		Diff:              res.Diff,
+		CommitMessages:    res.CommitMessages,
+		DependencyChanges: deps.DetectChanges(res.Diff),
+		Changelog:         res.Changelog,
+		VersionA:          versionA,
+		VersionB:          versionB,
+		ProgramName:       name,
+	}
+}
@@ -0,0 +1,171 @@
+package ucd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/tstromberg/ucd/pkg/ucd/gitwalk"
+)
+
+// openOrCloneRepo opens repoURL in-process via go-git. If repoURL points at a
+// local path, it is opened directly; otherwise it is cloned into a temporary
+// directory, whose path is returned so the caller can clean it up.
+func openOrCloneRepo(repoURL string) (repo *git.Repository, tempDir string, err error) {
+	if repo, err = git.PlainOpen(repoURL); err == nil {
+		return repo, "", nil
+	}
+
+	tempDir, err = os.MkdirTemp("", "ucd-git-*")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	repo, err = git.PlainClone(tempDir, false, &git.CloneOptions{URL: repoURL})
+	if err != nil {
+		removeAll(tempDir)
+		return nil, "", fmt.Errorf("git clone failed: %w", err)
+	}
+
+	return repo, tempDir, nil
+}
+
+// resolveRevision resolves a tag, branch, short SHA, or HEAD~N style
+// revision to a commit hash.
+func resolveRevision(repo *git.Repository, rev string) (*plumbing.Hash, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, fmt.Errorf("resolve revision %q: %w", rev, err)
+	}
+	return hash, nil
+}
+
+// commitAtRevision resolves rev to its commit object.
+func commitAtRevision(repo *git.Repository, rev string) (*object.Commit, error) {
+	hash, err := resolveRevision(repo, rev)
+	if err != nil {
+		return nil, err
+	}
+	return repo.CommitObject(*hash)
+}
+
+// treeAtRevision resolves rev to its tree object.
+func treeAtRevision(repo *git.Repository, rev string) (*object.Tree, error) {
+	commit, err := commitAtRevision(repo, rev)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
+
+// blobAtRevision returns the contents of path as it exists at rev.
+func blobAtRevision(repo *git.Repository, rev, path string) (string, error) {
+	tree, err := treeAtRevision(repo, rev)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := tree.File(path)
+	if err != nil {
+		return "", fmt.Errorf("file %q at %s: %w", path, rev, err)
+	}
+
+	return f.Contents()
+}
+
+// diffBetweenRevisions produces a unified diff of the full tree between
+// versionA and versionB using go-git's own patch types.
+func diffBetweenRevisions(repo *git.Repository, versionA, versionB string) (string, error) {
+	commitA, err := commitAtRevision(repo, versionA)
+	if err != nil {
+		return "", err
+	}
+	commitB, err := commitAtRevision(repo, versionB)
+	if err != nil {
+		return "", err
+	}
+
+	patch, err := commitA.Patch(commitB)
+	if err != nil {
+		return "", fmt.Errorf("compute patch: %w", err)
+	}
+
+	return patch.String(), nil
+}
+
+// commitsBetweenRevisions returns every commit reachable from versionB but
+// not versionA, newest first, matching the range `git log A..B` walks.
+func commitsBetweenRevisions(repo *git.Repository, versionA, versionB string) ([]*object.Commit, error) {
+	hashA, err := resolveRevision(repo, versionA)
+	if err != nil {
+		return nil, err
+	}
+	hashB, err := resolveRevision(repo, versionB)
+	if err != nil {
+		return nil, err
+	}
+
+	return gitwalk.CommitsBetween(repo, *hashA, *hashB)
+}
+
+// addedLines returns the lines diffmatchpatch considers inserted when going
+// from a to b, in the order they appear in b. This mirrors the previous
+// `diff -u | grep '^+'` behavior without shelling out.
+//
+// The diff runs in diffmatchpatch's line mode (DiffLinesToChars maps each
+// line to a single rune, diffs those, then DiffCharsToLines expands the
+// result back out) rather than DiffMain directly on the raw text, which
+// diffs character-by-character and can split an inserted line's text
+// across a change's middle, garbling anything downstream that expects
+// line-anchored output (the LLM prompt, changelogHasVersionHeading).
+func addedLines(a, b string) string {
+	dmp := diffmatchpatch.New()
+	aChars, bChars, lines := dmp.DiffLinesToChars(a, b)
+	diffs := dmp.DiffMain(aChars, bChars, false)
+	diffs = dmp.DiffCharsToLines(diffs, lines)
+
+	var added string
+	for _, d := range diffs {
+		if d.Type == diffmatchpatch.DiffInsert {
+			added += d.Text
+		}
+	}
+	return strings.TrimSuffix(added, "\n")
+}
+
+// removeAll best-effort removes a temporary clone directory.
+func removeAll(dir string) {
+	if dir != "" {
+		os.RemoveAll(dir)
+	}
+}
+
+// findChangelogFile locates the first matching changelog file in the tree
+// at rev.
+func findChangelogFile(repo *git.Repository, rev string) (string, error) {
+	tree, err := treeAtRevision(repo, rev)
+	if err != nil {
+		return "", err
+	}
+
+	var found string
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if found != "" {
+			return nil
+		}
+		switch filepath.Base(f.Name) {
+		case "CHANGELOG.md", "CHANGELOG.txt", "CHANGELOG",
+			"changelog.md", "changelog.txt", "changelog",
+			"CHANGES.md", "changes.md":
+			found = f.Name
+		}
+		return nil
+	})
+	return found, err
+}
@@ -0,0 +1,76 @@
+// Package gitwalk provides a correct "git log A..B" style traversal over
+// a go-git repository, shared by every collector (git, registry) that
+// needs the set of commits reachable from one revision but not another.
+package gitwalk
+
+import (
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// CommitsBetween returns every commit reachable from b but not from a,
+// newest first, matching the range `git log a..b` walks.
+//
+// A naive DFS/preorder walk from b that stops the instant it encounters a
+// is wrong whenever history isn't linear: on a merge commit, stopping on
+// the first path to reach a abandons every other branch still being
+// walked, silently dropping commits that are genuinely reachable from b
+// and not from a. This instead computes the full ancestor set of a first,
+// then walks everything reachable from b, only stopping a branch once it
+// enters that ancestor set — which is safe, since ancestors of an
+// ancestor of a are themselves ancestors of a.
+func CommitsBetween(repo *git.Repository, a, b plumbing.Hash) ([]*object.Commit, error) {
+	ancestorsOfA, err := ancestorSet(repo, a)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*object.Commit
+	seen := make(map[plumbing.Hash]bool)
+	queue := []plumbing.Hash{b}
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+		if seen[h] || ancestorsOfA[h] {
+			continue
+		}
+		seen[h] = true
+
+		c, err := repo.CommitObject(h)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, c)
+		queue = append(queue, c.ParentHashes...)
+	}
+
+	sort.Slice(commits, func(i, j int) bool {
+		return commits[i].Committer.When.After(commits[j].Committer.When)
+	})
+	return commits, nil
+}
+
+// ancestorSet returns start and every commit reachable from it by
+// following parent pointers.
+func ancestorSet(repo *git.Repository, start plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	seen := make(map[plumbing.Hash]bool)
+	queue := []plumbing.Hash{start}
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+
+		c, err := repo.CommitObject(h)
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, c.ParentHashes...)
+	}
+	return seen, nil
+}
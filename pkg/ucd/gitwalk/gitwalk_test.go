@@ -0,0 +1,110 @@
+package gitwalk
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+var sig = &object.Signature{Name: "gitwalk-test", Email: "gitwalk-test@example.com", When: time.Unix(1700000000, 0)}
+
+func commit(t *testing.T, repo *git.Repository, file, message string, parents ...plumbing.Hash) plumbing.Hash {
+	t.Helper()
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+
+	f, err := wt.Filesystem.Create(file)
+	if err != nil {
+		t.Fatalf("create %s: %v", file, err)
+	}
+	if _, err := f.Write([]byte(message)); err != nil {
+		t.Fatalf("write %s: %v", file, err)
+	}
+	f.Close()
+	if _, err := wt.Add(file); err != nil {
+		t.Fatalf("add %s: %v", file, err)
+	}
+
+	opts := &git.CommitOptions{Author: sig}
+	if len(parents) > 0 {
+		opts.Parents = parents
+	}
+	hash, err := wt.Commit(message, opts)
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	return hash
+}
+
+// TestCommitsBetweenSurvivesMergeCommit covers the scenario a naive
+// DFS-stops-at-first-encounter walk gets wrong: versionA is the common
+// ancestor of both a mainline commit and a two-commit feature branch,
+// merged back via a two-parent merge commit. Every commit introduced by
+// the feature branch must still show up, not just the ones on whichever
+// parent path the walk happens to exhaust first.
+func TestCommitsBetweenSurvivesMergeCommit(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatalf("init repo: %v", err)
+	}
+
+	root := commit(t, repo, "f", "root commit")
+	master := commit(t, repo, "f", "master commit 1", root)
+	feature1 := commit(t, repo, "f", "feature commit 1", master)
+	feature2 := commit(t, repo, "f", "feature commit 2", feature1)
+	merge := commit(t, repo, "f", "Merge pull request #1 from feature", master, feature2)
+
+	commits, err := CommitsBetween(repo, root, merge)
+	if err != nil {
+		t.Fatalf("CommitsBetween: %v", err)
+	}
+
+	var gotSubjects []string
+	for _, c := range commits {
+		gotSubjects = append(gotSubjects, c.Message)
+	}
+	sort.Strings(gotSubjects)
+
+	wantSubjects := []string{
+		"Merge pull request #1 from feature",
+		"feature commit 1",
+		"feature commit 2",
+		"master commit 1",
+	}
+	sort.Strings(wantSubjects)
+
+	if len(gotSubjects) != len(wantSubjects) {
+		t.Fatalf("commits = %v, want %v", gotSubjects, wantSubjects)
+	}
+	for i := range wantSubjects {
+		if gotSubjects[i] != wantSubjects[i] {
+			t.Fatalf("commits = %v, want %v", gotSubjects, wantSubjects)
+		}
+	}
+}
+
+func TestCommitsBetweenEmptyRange(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatalf("init repo: %v", err)
+	}
+
+	root := commit(t, repo, "f", "root commit")
+
+	commits, err := CommitsBetween(repo, root, root)
+	if err != nil {
+		t.Fatalf("CommitsBetween: %v", err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("commits = %+v, want none between a revision and itself", commits)
+	}
+}
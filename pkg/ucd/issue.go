@@ -0,0 +1,123 @@
+package ucd
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Issue is a typed, per-file finding derived from an Assessment, meant to
+// feed scanner-aggregation pipelines that expect structured issues rather
+// than free-form descriptions (bomber-style purl handling, dracon-style
+// typed issues).
+type Issue struct {
+	Description string   `json:"description"`
+	MalwareRisk int      `json:"malware_risk"`
+	SilentPatch int      `json:"silent_patch"`
+	File        string   `json:"file,omitempty"`
+	LineStart   int      `json:"line_start,omitempty"`
+	LineEnd     int      `json:"line_end,omitempty"`
+	PURL        string   `json:"purl,omitempty"`
+	CWE         []string `json:"cwe,omitempty"`
+
+	// Fingerprint is a stable hash of this issue's identity (file, line
+	// range, normalized description, and sorted CWE tags), for
+	// de-duplication across runs.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Issues converts r's UndocumentedChanges into typed Issue values. The
+// Summary assessment is excluded: it describes the combined impact, not a
+// single addressable finding.
+func (r *Result) Issues() []Issue {
+	issues := make([]Issue, 0, len(r.UndocumentedChanges))
+	for _, a := range r.UndocumentedChanges {
+		issues = append(issues, issueFromAssessment(a))
+	}
+	return issues
+}
+
+// issueFromAssessment converts one Assessment into an Issue and computes
+// its fingerprint.
+func issueFromAssessment(a Assessment) Issue {
+	issue := Issue{
+		Description: a.Description,
+		MalwareRisk: a.MalwareRisk,
+		SilentPatch: a.SilentPatch,
+		File:        a.File,
+		LineStart:   a.LineStart,
+		LineEnd:     a.LineEnd,
+		PURL:        a.PURL,
+		CWE:         a.CWE,
+	}
+	issue.Fingerprint = fingerprint(issue)
+	return issue
+}
+
+// fingerprint computes a stable de-duplication hash from an issue's file,
+// line range, normalized description, and sorted CWE tags. Risk scores
+// are deliberately excluded: the same underlying finding can be
+// re-scored slightly differently across runs without being a new issue.
+func fingerprint(i Issue) string {
+	cwe := append([]string(nil), i.CWE...)
+	sort.Strings(cwe)
+
+	parts := []string{
+		i.File,
+		strconv.Itoa(i.LineStart),
+		strconv.Itoa(i.LineEnd),
+		normalizeForMatch(i.Description),
+		strings.Join(cwe, ","),
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// WriteIssuesJSON writes issues to w as a formatted JSON array.
+func WriteIssuesJSON(w io.Writer, issues []Issue) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
+
+// issueCSVHeader names the columns WriteIssuesCSV writes.
+var issueCSVHeader = []string{
+	"fingerprint", "file", "line_start", "line_end", "purl", "cwe",
+	"malware_risk", "silent_patch", "description",
+}
+
+// WriteIssuesCSV writes issues to w as CSV, for tools that expect a flat
+// table rather than nested JSON.
+func WriteIssuesCSV(w io.Writer, issues []Issue) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(issueCSVHeader); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	for _, i := range issues {
+		record := []string{
+			i.Fingerprint,
+			i.File,
+			strconv.Itoa(i.LineStart),
+			strconv.Itoa(i.LineEnd),
+			i.PURL,
+			strings.Join(i.CWE, ";"),
+			strconv.Itoa(i.MalwareRisk),
+			strconv.Itoa(i.SilentPatch),
+			i.Description,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("write record: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,122 @@
+package ucd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/tstromberg/ucd/pkg/ucd/provider"
+)
+
+// PayloadComponent identifies one repository within a multi-repo release
+// payload (operator + N components, a services monorepo, a Kubernetes
+// release image, etc).
+type PayloadComponent struct {
+	Name     string `yaml:"name" json:"name"`
+	RepoURL  string `yaml:"repo_url" json:"repo_url"`
+	VersionA string `yaml:"version_a" json:"version_a"`
+	VersionB string `yaml:"version_b" json:"version_b"`
+}
+
+// PayloadManifest lists every component that makes up a release payload.
+type PayloadManifest struct {
+	Components []PayloadComponent `yaml:"components" json:"components"`
+}
+
+// ParsePayloadManifest parses a YAML or JSON payload manifest. JSON is a
+// YAML subset, so a single yaml.Unmarshal handles both.
+func ParsePayloadManifest(data []byte) (*PayloadManifest, error) {
+	var m PayloadManifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse payload manifest: %w", err)
+	}
+	if len(m.Components) == 0 {
+		return nil, fmt.Errorf("payload manifest has no components")
+	}
+	return &m, nil
+}
+
+// ComponentResult is one component's analysis outcome within a payload run.
+type ComponentResult struct {
+	Name   string `json:"name"`
+	Error  string `json:"error,omitempty"`
+	Result *Result `json:"result,omitempty"`
+}
+
+// PayloadResult aggregates the analysis of every component in a payload.
+type PayloadResult struct {
+	Components []ComponentResult `json:"components"`
+}
+
+// HighRiskComponents returns the components whose summary crossed into
+// High risk (>6) for either malware_risk or silent_patch.
+func (p *PayloadResult) HighRiskComponents() []ComponentResult {
+	var high []ComponentResult
+	for _, c := range p.Components {
+		if c.Result == nil || c.Result.Summary == nil {
+			continue
+		}
+		if c.Result.Summary.MalwareRisk > 6 || c.Result.Summary.SilentPatch > 6 {
+			high = append(high, c)
+		}
+	}
+	return high
+}
+
+// defaultPayloadWorkers bounds concurrency when CollectPayload is called
+// with workers <= 0.
+const defaultPayloadWorkers = 4
+
+// CollectPayload runs collection and analysis for every component in
+// manifest, in parallel across a bounded worker pool, and aggregates the
+// results. A single component's failure doesn't abort the others; its
+// ComponentResult.Error is set instead.
+func CollectPayload(ctx context.Context, p provider.Provider, manifest *PayloadManifest, modelName string, workers int) (*PayloadResult, error) {
+	if workers <= 0 {
+		workers = defaultPayloadWorkers
+	}
+
+	results := make([]ComponentResult, len(manifest.Components))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i] = analyzeComponent(ctx, p, manifest.Components[i], modelName)
+			}
+		}()
+	}
+
+	for i := range manifest.Components {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return &PayloadResult{Components: results}, nil
+}
+
+// analyzeComponent collects and analyzes a single payload component.
+func analyzeComponent(ctx context.Context, p provider.Provider, c PayloadComponent, modelName string) ComponentResult {
+	data, err := Collect(Config{
+		RepoURL:     c.RepoURL,
+		VersionA:    c.VersionA,
+		VersionB:    c.VersionB,
+		ProgramName: c.Name,
+	})
+	if err != nil {
+		return ComponentResult{Name: c.Name, Error: fmt.Sprintf("collect: %v", err)}
+	}
+
+	result, err := AnalyzeChanges(ctx, p, data, modelName)
+	if err != nil {
+		return ComponentResult{Name: c.Name, Error: fmt.Sprintf("analyze: %v", err)}
+	}
+
+	return ComponentResult{Name: c.Name, Result: result}
+}
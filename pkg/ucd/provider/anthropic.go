@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// defaultAnthropicBaseURL is the public Anthropic Messages API endpoint.
+const defaultAnthropicBaseURL = "https://api.anthropic.com/v1/messages"
+
+// defaultAnthropicModel is used when GenerateOptions.Model is empty.
+const defaultAnthropicModel = "claude-sonnet-4-20250514"
+
+// anthropicVersion is the Messages API version this client speaks.
+const anthropicVersion = "2023-06-01"
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature float32            `json:"temperature"`
+	Messages    []anthropicMessage `json:"messages"`
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// defaultAnthropicMaxTokens bounds the response when the caller doesn't
+// otherwise constrain it; the Messages API requires max_tokens.
+const defaultAnthropicMaxTokens = 8192
+
+// AnthropicProvider calls Anthropic's Messages API.
+type AnthropicProvider struct {
+	apiKey  string
+	baseURL string
+}
+
+// NewAnthropicProvider creates a Provider backed by Anthropic's Messages API.
+func NewAnthropicProvider(cfg Config) (*AnthropicProvider, error) {
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("anthropic: api key is required")
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultAnthropicBaseURL
+	}
+	return &AnthropicProvider{apiKey: cfg.APIKey, baseURL: baseURL}, nil
+}
+
+// Generate implements Provider.
+func (p *AnthropicProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, Usage, error) {
+	modelName := opts.Model
+	if modelName == "" {
+		modelName = defaultAnthropicModel
+	}
+
+	reqBody := anthropicRequest{
+		Model:       modelName,
+		MaxTokens:   defaultAnthropicMaxTokens,
+		Temperature: opts.Temperature,
+		Messages:    []anthropicMessage{{Role: "user", Content: prompt}},
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", Usage{}, fmt.Errorf("marshal request: %w", err)
+	}
+
+	var text string
+	var usage Usage
+	err = withRetry(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("build request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-api-key", p.apiKey)
+		req.Header.Set("anthropic-version", anthropicVersion)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("do request: %w", err)
+		}
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("read response: %w", err)
+		}
+
+		var parsed anthropicResponse
+		if err := json.Unmarshal(respBody, &parsed); err != nil {
+			return fmt.Errorf("decode response (status %s): %w", resp.Status, err)
+		}
+		if parsed.Error != nil {
+			return fmt.Errorf("api error: %s", parsed.Error.Message)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		if len(parsed.Content) == 0 {
+			return fmt.Errorf("no content in response")
+		}
+
+		text = parsed.Content[0].Text
+		usage = Usage{PromptTokens: parsed.Usage.InputTokens, CompletionTokens: parsed.Usage.OutputTokens}
+		return nil
+	})
+	if err != nil {
+		return "", Usage{}, err
+	}
+	return text, usage, nil
+}
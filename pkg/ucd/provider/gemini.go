@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// defaultGeminiModel is used when GenerateOptions.Model is empty.
+const defaultGeminiModel = "gemini-2.5-pro-preview-05-06"
+
+// GeminiProvider calls the Gemini API via google.golang.org/genai.
+type GeminiProvider struct {
+	client *genai.Client
+}
+
+// NewGeminiProvider creates a Provider backed by the Gemini API.
+func NewGeminiProvider(ctx context.Context, cfg Config) (*GeminiProvider, error) {
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+		APIKey:  cfg.APIKey,
+		Backend: genai.BackendGeminiAPI,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create gemini client: %w", err)
+	}
+	return &GeminiProvider{client: client}, nil
+}
+
+// Generate implements Provider.
+func (p *GeminiProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, Usage, error) {
+	modelName := opts.Model
+	if modelName == "" {
+		modelName = defaultGeminiModel
+	}
+
+	genConfig := &genai.GenerateContentConfig{
+		Temperature: genai.Ptr(opts.Temperature),
+		Seed:        genai.Ptr(opts.Seed),
+	}
+
+	var text string
+	var usage Usage
+	err := withRetry(ctx, func() error {
+		resp, err := p.client.Models.GenerateContent(ctx, modelName, genai.Text(prompt), genConfig)
+		if err != nil {
+			return fmt.Errorf("generate content: %w", err)
+		}
+		if resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil || len(resp.Candidates[0].Content.Parts) == 0 {
+			return fmt.Errorf("no response or valid parts from gemini")
+		}
+
+		var b strings.Builder
+		for _, part := range resp.Candidates[0].Content.Parts {
+			if part.Text != "" {
+				b.WriteString(part.Text)
+			}
+		}
+		text = b.String()
+
+		if resp.UsageMetadata != nil {
+			usage = Usage{
+				PromptTokens:     int(resp.UsageMetadata.PromptTokenCount),
+				CompletionTokens: int(resp.UsageMetadata.CandidatesTokenCount),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", Usage{}, err
+	}
+	return text, usage, nil
+}
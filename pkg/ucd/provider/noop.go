@@ -0,0 +1,53 @@
+package provider
+
+import "context"
+
+// NoopProvider returns its fixed Text without making any network call.
+// Useful for tests and dry runs.
+type NoopProvider struct {
+	Text  string
+	Usage Usage
+}
+
+// NewNoopProvider returns a Provider that always responds with an empty
+// JSON array ("[]") and does nothing else.
+func NewNoopProvider() *NoopProvider {
+	return &NoopProvider{Text: "[]"}
+}
+
+// Generate implements Provider.
+func (p *NoopProvider) Generate(_ context.Context, _ string, _ GenerateOptions) (string, Usage, error) {
+	return p.Text, p.Usage, nil
+}
+
+// RecordedProvider replays a fixed sequence of responses, one per call,
+// and records every prompt it was given. Once exhausted, it repeats its
+// last response. Intended for tests that need to assert on the prompt
+// ucd built without making a real API call.
+type RecordedProvider struct {
+	Responses []string
+	Prompts   []string
+
+	calls int
+}
+
+// NewRecordedProvider returns a Provider that replays responses in order.
+func NewRecordedProvider(responses ...string) *RecordedProvider {
+	return &RecordedProvider{Responses: responses}
+}
+
+// Generate implements Provider.
+func (p *RecordedProvider) Generate(_ context.Context, prompt string, _ GenerateOptions) (string, Usage, error) {
+	p.Prompts = append(p.Prompts, prompt)
+
+	if len(p.Responses) == 0 {
+		return "[]", Usage{}, nil
+	}
+
+	i := p.calls
+	if i >= len(p.Responses) {
+		i = len(p.Responses) - 1
+	}
+	p.calls++
+	return p.Responses[i], Usage{}, nil
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNoopProviderGenerate(t *testing.T) {
+	p := NewNoopProvider()
+
+	text, usage, err := p.Generate(context.Background(), "anything", GenerateOptions{Model: "ignored"})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if text != "[]" {
+		t.Errorf("text = %q, want the empty-array placeholder %q", text, "[]")
+	}
+	if usage != (Usage{}) {
+		t.Errorf("usage = %+v, want the zero value", usage)
+	}
+}
+
+func TestNoopProviderCustomText(t *testing.T) {
+	p := &NoopProvider{Text: `{"undocumented_changes":[]}`}
+
+	text, _, err := p.Generate(context.Background(), "anything", GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if text != `{"undocumented_changes":[]}` {
+		t.Errorf("text = %q, want the configured Text field echoed back", text)
+	}
+}
+
+func TestRecordedProviderReplaysInOrderThenRepeatsLast(t *testing.T) {
+	p := NewRecordedProvider("first", "second")
+
+	for i, want := range []string{"first", "second", "second", "second"} {
+		text, _, err := p.Generate(context.Background(), "prompt", GenerateOptions{})
+		if err != nil {
+			t.Fatalf("call %d: Generate: %v", i, err)
+		}
+		if text != want {
+			t.Errorf("call %d: text = %q, want %q", i, text, want)
+		}
+	}
+
+	if len(p.Prompts) != 4 {
+		t.Fatalf("Prompts = %v, want 4 recorded prompts", p.Prompts)
+	}
+	for i, prompt := range p.Prompts {
+		if prompt != "prompt" {
+			t.Errorf("Prompts[%d] = %q, want %q", i, prompt, "prompt")
+		}
+	}
+}
+
+func TestRecordedProviderNoResponsesConfigured(t *testing.T) {
+	p := NewRecordedProvider()
+
+	text, _, err := p.Generate(context.Background(), "prompt", GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if text != "[]" {
+		t.Errorf("text = %q, want the empty-array placeholder when no responses were configured", text)
+	}
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+)
+
+// defaultOllamaBaseURL is where Ollama listens by default.
+const defaultOllamaBaseURL = "http://localhost:11434/v1"
+
+// defaultOllamaModel is used when GenerateOptions.Model is empty.
+const defaultOllamaModel = "llama3"
+
+// OllamaProvider calls a local (or self-hosted) Ollama instance through
+// its OpenAI-compatible endpoint, so ucd can run fully offline.
+type OllamaProvider struct {
+	baseURL string
+}
+
+// NewOllamaProvider creates a Provider backed by an Ollama/OpenAI-compatible
+// HTTP endpoint. cfg.BaseURL overrides the default localhost address.
+func NewOllamaProvider(cfg Config) (*OllamaProvider, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultOllamaBaseURL
+	}
+	return &OllamaProvider{baseURL: baseURL}, nil
+}
+
+// Generate implements Provider.
+func (p *OllamaProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, Usage, error) {
+	text, usage, err := chatCompletion(ctx, p.baseURL+"/chat/completions", nil, prompt, opts, defaultOllamaModel)
+	if err != nil {
+		return "", Usage{}, fmt.Errorf("ollama: %w", err)
+	}
+	return text, usage, nil
+}
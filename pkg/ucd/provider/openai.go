@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// defaultOpenAIModel is used when GenerateOptions.Model is empty.
+const defaultOpenAIModel = "gpt-4o"
+
+// defaultOpenAIBaseURL is the public OpenAI API endpoint.
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// chatCompletionRequest is the OpenAI-compatible request body shared by
+// OpenAIProvider, AzureOpenAIProvider, and OllamaProvider.
+type chatCompletionRequest struct {
+	Model       string                  `json:"model"`
+	Messages    []chatCompletionMessage `json:"messages"`
+	Temperature float32                 `json:"temperature"`
+	Seed        *int32                  `json:"seed,omitempty"`
+}
+
+type chatCompletionMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatCompletionMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// OpenAIProvider calls OpenAI's chat completions API.
+type OpenAIProvider struct {
+	apiKey  string
+	baseURL string
+}
+
+// NewOpenAIProvider creates a Provider backed by the OpenAI API. cfg.BaseURL
+// overrides the default endpoint, for OpenAI-compatible gateways.
+func NewOpenAIProvider(cfg Config) (*OpenAIProvider, error) {
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("openai: api key is required")
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	return &OpenAIProvider{apiKey: cfg.APIKey, baseURL: baseURL}, nil
+}
+
+// Generate implements Provider.
+func (p *OpenAIProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, Usage, error) {
+	return chatCompletion(ctx, p.baseURL+"/chat/completions", map[string]string{
+		"Authorization": "Bearer " + p.apiKey,
+	}, prompt, opts, defaultOpenAIModel)
+}
+
+// AzureOpenAIProvider calls an Azure OpenAI deployment.
+type AzureOpenAIProvider struct {
+	apiKey   string
+	resource string
+	baseURL  string
+}
+
+// NewAzureOpenAIProvider creates a Provider backed by an Azure OpenAI
+// resource. cfg.Resource names the Azure resource; cfg.BaseURL overrides
+// the full endpoint when set (e.g. for a private Azure deployment).
+func NewAzureOpenAIProvider(cfg Config) (*AzureOpenAIProvider, error) {
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("azure-openai: api key is required")
+	}
+	if cfg.BaseURL == "" && cfg.Resource == "" {
+		return nil, fmt.Errorf("azure-openai: resource name or base URL is required")
+	}
+	return &AzureOpenAIProvider{apiKey: cfg.APIKey, resource: cfg.Resource, baseURL: cfg.BaseURL}, nil
+}
+
+// Generate implements Provider.
+func (p *AzureOpenAIProvider) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, Usage, error) {
+	modelName := opts.Model
+	if modelName == "" {
+		modelName = defaultOpenAIModel
+	}
+
+	url := p.baseURL
+	if url == "" {
+		url = fmt.Sprintf("https://%s.openai.azure.com/openai/deployments/%s/chat/completions?api-version=2024-06-01", p.resource, modelName)
+	}
+
+	return chatCompletion(ctx, url, map[string]string{
+		"api-key": p.apiKey,
+	}, prompt, opts, modelName)
+}
+
+// chatCompletion POSTs an OpenAI-compatible chat completion request and
+// extracts the first choice's text and usage. Shared by OpenAIProvider,
+// AzureOpenAIProvider, and OllamaProvider, which all speak this dialect.
+func chatCompletion(ctx context.Context, url string, headers map[string]string, prompt string, opts GenerateOptions, defaultModel string) (string, Usage, error) {
+	modelName := opts.Model
+	if modelName == "" {
+		modelName = defaultModel
+	}
+
+	reqBody := chatCompletionRequest{
+		Model:       modelName,
+		Messages:    []chatCompletionMessage{{Role: "user", Content: prompt}},
+		Temperature: opts.Temperature,
+	}
+	if opts.Seed != 0 {
+		reqBody.Seed = &opts.Seed
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", Usage{}, fmt.Errorf("marshal request: %w", err)
+	}
+
+	var text string
+	var usage Usage
+	err = withRetry(ctx, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("build request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("do request: %w", err)
+		}
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("read response: %w", err)
+		}
+
+		var parsed chatCompletionResponse
+		if err := json.Unmarshal(respBody, &parsed); err != nil {
+			return fmt.Errorf("decode response (status %s): %w", resp.Status, err)
+		}
+		if parsed.Error != nil {
+			return fmt.Errorf("api error: %s", parsed.Error.Message)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		if len(parsed.Choices) == 0 {
+			return fmt.Errorf("no choices in response")
+		}
+
+		text = parsed.Choices[0].Message.Content
+		usage = Usage{PromptTokens: parsed.Usage.PromptTokens, CompletionTokens: parsed.Usage.CompletionTokens}
+		return nil
+	})
+	if err != nil {
+		return "", Usage{}, err
+	}
+	return text, usage, nil
+}
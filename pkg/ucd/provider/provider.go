@@ -0,0 +1,97 @@
+// Package provider abstracts the LLM backend ucd sends its analysis
+// prompt to, so the rest of the codebase doesn't need to know whether
+// it's talking to Gemini, OpenAI, Anthropic, or a local Ollama endpoint.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// GenerateOptions configures a single Generate call.
+type GenerateOptions struct {
+	Model       string
+	Temperature float32
+	Seed        int32
+}
+
+// Usage reports the token accounting for a Generate call, when the
+// backend provides it. Providers that don't report usage leave it zeroed.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// Provider generates text from a prompt against a specific AI backend.
+type Provider interface {
+	Generate(ctx context.Context, prompt string, opts GenerateOptions) (text string, usage Usage, err error)
+}
+
+// Backend identifies a selectable Provider implementation, e.g. via the
+// --ai-backend flag.
+type Backend string
+
+const (
+	Gemini      Backend = "gemini"
+	OpenAI      Backend = "openai"
+	AzureOpenAI Backend = "azure-openai"
+	Anthropic   Backend = "anthropic"
+	Ollama      Backend = "ollama"
+	Noop        Backend = "noop"
+)
+
+// Config holds the fields needed to construct any Provider. Not every
+// field applies to every backend; see each New*Provider for which it reads.
+type Config struct {
+	APIKey   string
+	BaseURL  string // overrides the default endpoint (Azure OpenAI, Ollama, OpenAI-compatible gateways)
+	Resource string // Azure OpenAI resource name
+}
+
+// New constructs the Provider for the named backend.
+func New(ctx context.Context, backend Backend, cfg Config) (Provider, error) {
+	switch backend {
+	case Gemini, "":
+		return NewGeminiProvider(ctx, cfg)
+	case OpenAI:
+		return NewOpenAIProvider(cfg)
+	case AzureOpenAI:
+		return NewAzureOpenAIProvider(cfg)
+	case Anthropic:
+		return NewAnthropicProvider(cfg)
+	case Ollama:
+		return NewOllamaProvider(cfg)
+	case Noop:
+		return NewNoopProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown ai-backend %q", backend)
+	}
+}
+
+// maxRetries bounds the retry/backoff helper shared by every HTTP-based
+// provider, guarding against a single transient failure aborting analysis.
+const maxRetries = 3
+
+// withRetry calls fn up to maxRetries times, backing off with jitter
+// between attempts. It returns the last error if every attempt fails.
+func withRetry(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(attempt) * time.Second
+			jitter := time.Duration(rand.Intn(250)) * time.Millisecond
+			select {
+			case <-time.After(backoff + jitter):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if err = fn(); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("after %d attempts: %w", maxRetries, err)
+}
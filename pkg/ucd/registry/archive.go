@@ -0,0 +1,228 @@
+package registry
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// download fetches url's body into a temp file under dir and returns its
+// path.
+func download(ctx context.Context, dir, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch %s: unexpected status %s", url, resp.Status)
+	}
+
+	dst := filepath.Join(dir, "archive")
+	f, err := os.Create(dst)
+	if err != nil {
+		return "", fmt.Errorf("create %s: %w", dst, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return "", fmt.Errorf("download %s: %w", url, err)
+	}
+	return dst, nil
+}
+
+// extractTarGz extracts a gzip-compressed tarball at src into dest,
+// rejecting any entry that would escape dest (path traversal via "../").
+func extractTarGz(src, dest string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("gunzip %s: %w", src, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read tar entry: %w", err)
+		}
+
+		target, err := safeJoin(dest, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractZip extracts a zip archive at src into dest, rejecting any entry
+// that would escape dest.
+func extractZip(src, dest string) error {
+	zr, err := zip.OpenReader(src)
+	if err != nil {
+		return fmt.Errorf("open zip %s: %w", src, err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		target, err := safeJoin(dest, f.Name)
+		if err != nil {
+			return err
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("open zip entry %s: %w", f.Name, err)
+		}
+		err = writeFile(target, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// safeJoin joins dest and name, rejecting paths that escape dest.
+func safeJoin(dest, name string) (string, error) {
+	target := filepath.Join(dest, name)
+	if target != dest && !strings.HasPrefix(target, dest+string(os.PathSeparator)) {
+		return "", fmt.Errorf("archive entry %q escapes extraction directory", name)
+	}
+	return target, nil
+}
+
+// writeFile writes r to target, creating target's parent directory first.
+func writeFile(target string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	w, err := os.Create(target)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", target, err)
+	}
+	defer w.Close()
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// extractorFor picks extractTarGz or extractZip based on dist's file
+// extension, since PyPI serves both sdists (.tar.gz) and wheels (.whl,
+// a zip file) from the same "urls" list.
+func extractorFor(url string) func(src, dest string) error {
+	if strings.HasSuffix(url, ".tar.gz") || strings.HasSuffix(url, ".tgz") {
+		return extractTarGz
+	}
+	return extractZip
+}
+
+// diffTarballs downloads two gzip tarballs and diffs their extracted
+// contents.
+func diffTarballs(ctx context.Context, urlA, urlB string) (string, error) {
+	return diffArchives(ctx, urlA, extractTarGz, urlB, extractTarGz)
+}
+
+// diffZips downloads two zip archives and diffs their extracted
+// contents.
+func diffZips(ctx context.Context, urlA, urlB string) (string, error) {
+	return diffArchives(ctx, urlA, extractZip, urlB, extractZip)
+}
+
+// diffArchives downloads urlA and urlB, extracts each with its own
+// extractor function, and diffs the resulting trees.
+func diffArchives(ctx context.Context, urlA string, extractA func(string, string) error, urlB string, extractB func(string, string) error) (string, error) {
+	dir, err := os.MkdirTemp("", "ucd-registry-*")
+	if err != nil {
+		return "", fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dirA, err := fetchAndExtract(ctx, dir, "a", urlA, extractA)
+	if err != nil {
+		return "", err
+	}
+	dirB, err := fetchAndExtract(ctx, dir, "b", urlB, extractB)
+	if err != nil {
+		return "", err
+	}
+
+	return diffTrees(dirA, dirB)
+}
+
+// fetchAndExtract downloads url into a subdirectory of parent and
+// extracts it with extract, then descends into the archive's wrapper
+// directory if it has exactly one (the layout npm tarballs, PyPI
+// sdists, and Go module zips all use), so two archives named
+// differently at the top level still diff their actual contents
+// against each other.
+func fetchAndExtract(ctx context.Context, parent, name, url string, extract func(src, dest string) error) (string, error) {
+	side := filepath.Join(parent, name)
+	if err := os.MkdirAll(side, 0o755); err != nil {
+		return "", err
+	}
+
+	archivePath, err := download(ctx, side, url)
+	if err != nil {
+		return "", err
+	}
+
+	extracted := filepath.Join(side, "extracted")
+	if err := os.MkdirAll(extracted, 0o755); err != nil {
+		return "", err
+	}
+	if err := extract(archivePath, extracted); err != nil {
+		return "", fmt.Errorf("extract %s: %w", url, err)
+	}
+	return singleChildDir(extracted), nil
+}
+
+// singleChildDir descends into dir's sole child directory, repeatedly,
+// as long as dir contains exactly one entry and it's a directory.
+func singleChildDir(dir string) string {
+	for {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+			return dir
+		}
+		dir = filepath.Join(dir, entries[0].Name())
+	}
+}
@@ -0,0 +1,149 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"k8s.io/klog/v2"
+
+	"github.com/tstromberg/ucd/pkg/ucd/gitwalk"
+)
+
+// githubRepoPattern extracts the owner/repo portion of a GitHub source
+// repository URL, however the registry's metadata happened to format it
+// (with or without a "https://", a ".git" suffix, or a "git+" scheme).
+var githubRepoPattern = regexp.MustCompile(`github\.com[:/]([^/]+)/([^/.]+)`)
+
+// changelogCandidates are the filenames checked, in order, for a
+// release-notes document in a GitHub repository.
+var changelogCandidates = []string{"CHANGELOG.md", "CHANGES.md", "HISTORY.md", "CHANGELOG.rst"}
+
+// fetchGitHubContext is a best-effort enrichment step: given a package's
+// declared source repository and the two versions being compared, it
+// tries to find commit messages and a changelog to go alongside the
+// tree diff. Registry metadata linking to a source repo is common but
+// not guaranteed to be accurate or present, so every failure here is
+// swallowed and logged rather than propagated — an ecosystem collector
+// should still return a usable diff-only AnalysisData when it can't.
+func fetchGitHubContext(ctx context.Context, repoURL, versionA, versionB string) (commitMessages, changelog string) {
+	owner, repo, ok := parseGitHubRepo(repoURL)
+	if !ok {
+		return "", ""
+	}
+
+	changelog = fetchGitHubChangelog(ctx, owner, repo, versionB)
+	commitMessages = fetchGitHubCommitLog(owner, repo, versionA, versionB)
+	return commitMessages, changelog
+}
+
+// parseGitHubRepo extracts the owner and repo name from a GitHub URL in
+// any of the forms package registries tend to use it in.
+func parseGitHubRepo(repoURL string) (owner, repo string, ok bool) {
+	m := githubRepoPattern.FindStringSubmatch(repoURL)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], strings.TrimSuffix(m[2], ".git"), true
+}
+
+// fetchGitHubChangelog tries each of changelogCandidates against ref,
+// returning the first one GitHub serves.
+func fetchGitHubChangelog(ctx context.Context, owner, repo, ref string) string {
+	for _, name := range changelogCandidates {
+		url := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", owner, repo, ref, name)
+		text, err := fetchText(ctx, url)
+		if err == nil {
+			return text
+		}
+	}
+	klog.V(1).Infof("no changelog found for %s/%s@%s", owner, repo, ref)
+	return ""
+}
+
+// fetchText fetches url and returns its body as text, failing on any
+// non-200 response.
+func fetchText(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: unexpected status %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	return string(body), err
+}
+
+// commitLogCloneDepth bounds fetchGitHubCommitLog's clone to a window of
+// history generous enough to cover the gap between two adjacent-ish
+// releases without pulling a project's full history on every run.
+const commitLogCloneDepth = 1000
+
+// fetchGitHubCommitLog shallow-clones owner/repo and returns the subject
+// lines of every commit between versionA and versionB, trying both the
+// bare version string and a "v"-prefixed tag, since tagging conventions
+// vary across ecosystems.
+func fetchGitHubCommitLog(owner, repo, versionA, versionB string) string {
+	url := fmt.Sprintf("https://github.com/%s/%s", owner, repo)
+
+	dir, err := os.MkdirTemp("", "ucd-registry-git-*")
+	if err != nil {
+		return ""
+	}
+	defer os.RemoveAll(dir)
+
+	r, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL:   url,
+		Depth: commitLogCloneDepth,
+		Tags:  git.AllTags,
+	})
+	if err != nil {
+		klog.V(1).Infof("clone %s: %v", url, err)
+		return ""
+	}
+
+	hashA, okA := resolveTag(r, versionA)
+	hashB, okB := resolveTag(r, versionB)
+	if !okA || !okB {
+		klog.V(1).Infof("couldn't resolve tags for %s/%s between %q and %q", owner, repo, versionA, versionB)
+		return ""
+	}
+
+	commits, err := gitwalk.CommitsBetween(r, hashA, hashB)
+	if err != nil {
+		klog.V(1).Infof("commit log %s/%s between %q and %q: %v", owner, repo, versionA, versionB, err)
+		return ""
+	}
+
+	subjects := make([]string, len(commits))
+	for i, c := range commits {
+		subjects[i] = strings.SplitN(c.Message, "\n", 2)[0]
+	}
+	return strings.Join(subjects, "\n")
+}
+
+// resolveTag resolves version against both its bare form and a
+// "v"-prefixed form, since some ecosystems tag "1.2.3" and others
+// "v1.2.3".
+func resolveTag(r *git.Repository, version string) (plumbing.Hash, bool) {
+	for _, rev := range []string{version, "v" + strings.TrimPrefix(version, "v")} {
+		if hash, err := r.ResolveRevision(plumbing.Revision(rev)); err == nil {
+			return *hash, true
+		}
+	}
+	return plumbing.ZeroHash, false
+}
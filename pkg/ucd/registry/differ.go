@@ -0,0 +1,152 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// diffTrees produces a unified diff between two extracted package trees,
+// in the same "diff --git a/path b/path" / "@@ -a,b +c,d @@" shape the
+// rest of this repo's diffs use (see gitutil.go's use of go-git's own
+// patch formatting), so downstream consumers like pkg/ucd/verifier and
+// pkg/ucd/sarif parse it the same way regardless of where it came from.
+func diffTrees(dirA, dirB string) (string, error) {
+	filesA, err := listFiles(dirA)
+	if err != nil {
+		return "", fmt.Errorf("list %s: %w", dirA, err)
+	}
+	filesB, err := listFiles(dirB)
+	if err != nil {
+		return "", fmt.Errorf("list %s: %w", dirB, err)
+	}
+
+	paths := make(map[string]bool)
+	for p := range filesA {
+		paths[p] = true
+	}
+	for p := range filesB {
+		paths[p] = true
+	}
+
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	var out strings.Builder
+	for _, p := range sorted {
+		a, inA := filesA[p]
+		b, inB := filesB[p]
+
+		switch {
+		case inA && inB:
+			if a.hash == b.hash {
+				continue
+			}
+			out.WriteString(diffFile(p, a, b))
+		case inA:
+			out.WriteString(diffFile(p, a, fileContent{}))
+		case inB:
+			out.WriteString(diffFile(p, fileContent{}, b))
+		}
+	}
+
+	return out.String(), nil
+}
+
+// fileContent is a file's text content, read once and fingerprinted so
+// identical files across the two trees can be skipped without re-diffing.
+type fileContent struct {
+	text string
+	hash string
+}
+
+// listFiles walks dir and returns every regular file's content, keyed by
+// its path relative to dir. Binary files (content containing a NUL byte)
+// get a "Binary file differs" placeholder as their text, alongside the
+// hash of their real content, so they're still detected as changed/added/
+// removed without corrupting the diff text.
+func listFiles(dir string) (map[string]fileContent, error) {
+	files := make(map[string]fileContent)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		sum := sha256.Sum256(data)
+		text := string(data)
+		if strings.ContainsRune(text, 0) {
+			text = "Binary file differs\n"
+		}
+
+		files[filepath.ToSlash(rel)] = fileContent{text: text, hash: fmt.Sprintf("%x", sum)}
+		return nil
+	})
+	return files, err
+}
+
+// diffFile renders one file's change as a unified diff hunk using
+// diffmatchpatch's line-mode diff (DiffLinesToChars/DiffCharsToLines),
+// the same technique gitutil.go's addedLines uses to avoid shelling out
+// to diff(1).
+func diffFile(path string, a, b fileContent) string {
+	dmp := diffmatchpatch.New()
+	aChars, bChars, lines := dmp.DiffLinesToChars(a.text, b.text)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(aChars, bChars, false), lines)
+	diffs = dmp.DiffCleanupSemantic(diffs)
+
+	var body strings.Builder
+	var oldCount, newCount int
+	for _, d := range diffs {
+		n := strings.Count(d.Text, "\n")
+		if !strings.HasSuffix(d.Text, "\n") && d.Text != "" {
+			n++
+		}
+
+		var prefix string
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix, oldCount = "-", oldCount+n
+		case diffmatchpatch.DiffInsert:
+			prefix, newCount = "+", newCount+n
+		case diffmatchpatch.DiffEqual:
+			oldCount += n
+			newCount += n
+			prefix = " "
+		}
+
+		for _, line := range strings.Split(strings.TrimSuffix(d.Text, "\n"), "\n") {
+			body.WriteString(prefix + line + "\n")
+		}
+	}
+
+	var header strings.Builder
+	fmt.Fprintf(&header, "diff --git a/%s b/%s\n", path, path)
+	fmt.Fprintf(&header, "--- a/%s\n", path)
+	fmt.Fprintf(&header, "+++ b/%s\n", path)
+	fmt.Fprintf(&header, "@@ -1,%d +1,%d @@\n", oldCount, newCount)
+
+	return header.String() + body.String()
+}
@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// FetchGoModule downloads the two module zips from the Go module proxy,
+// diffs their extracted contents, and enriches the result with commit
+// messages and a changelog pulled from the module's source repository
+// (inferred from its import path, since Go modules don't carry separate
+// repository metadata the way npm and PyPI packages do).
+func FetchGoModule(ctx context.Context, module, versionA, versionB string) (*FetchResult, error) {
+	urlA, err := goProxyZipURL(module, versionA)
+	if err != nil {
+		return nil, err
+	}
+	urlB, err := goProxyZipURL(module, versionB)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := diffZips(ctx, urlA, urlB)
+	if err != nil {
+		return nil, err
+	}
+
+	repoURL := goModuleRepoURL(module)
+	commits, changelog := fetchGitHubContext(ctx, repoURL, versionA, versionB)
+
+	return &FetchResult{Diff: diff, CommitMessages: commits, Changelog: changelog, RepoURL: repoURL}, nil
+}
+
+// goProxyZipURL builds a Go module proxy URL for module@version,
+// applying the proxy's case-encoding (an uppercase letter becomes "!"
+// followed by its lowercase form) so mixed-case import paths resolve.
+func goProxyZipURL(module, version string) (string, error) {
+	escaped, err := escapeModulePath(module)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://proxy.golang.org/%s/@v/%s.zip", escaped, version), nil
+}
+
+func escapeModulePath(path string) (string, error) {
+	var b strings.Builder
+	for _, r := range path {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			b.WriteByte('!')
+			b.WriteRune(r - 'A' + 'a')
+		case r == '!':
+			return "", fmt.Errorf("invalid module path %q", path)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), nil
+}
+
+// goModuleRepoURL derives a source repository URL from a Go module
+// path. Most modules are hosted directly at their import path (the
+// common case this handles); a module behind a custom vanity domain
+// won't resolve to a usable repository this way, which this collector
+// treats as best-effort rather than an error.
+func goModuleRepoURL(module string) string {
+	parts := strings.SplitN(module, "/", 4)
+	if len(parts) < 3 {
+		return "https://" + module
+	}
+	return "https://" + strings.Join(parts[:3], "/")
+}
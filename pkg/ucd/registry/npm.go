@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// npmPackageMeta is the subset of an npm registry package document this
+// collector needs: per-version tarball and repository links.
+type npmPackageMeta struct {
+	Versions map[string]struct {
+		Dist struct {
+			Tarball string `json:"tarball"`
+		} `json:"dist"`
+		Repository struct {
+			URL string `json:"url"`
+		} `json:"repository"`
+	} `json:"versions"`
+}
+
+// FetchNpm downloads the two published tarballs for name, diffs their
+// extracted contents, and enriches the result with commit messages and
+// a changelog pulled from the package's declared source repository, if
+// npm knows about one.
+func FetchNpm(ctx context.Context, name, versionA, versionB string) (*FetchResult, error) {
+	meta, err := fetchNpmMeta(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	metaA, ok := meta.Versions[versionA]
+	if !ok {
+		return nil, fmt.Errorf("npm package %s has no version %s", name, versionA)
+	}
+	metaB, ok := meta.Versions[versionB]
+	if !ok {
+		return nil, fmt.Errorf("npm package %s has no version %s", name, versionB)
+	}
+
+	diff, err := diffTarballs(ctx, metaA.Dist.Tarball, metaB.Dist.Tarball)
+	if err != nil {
+		return nil, err
+	}
+
+	repoURL := metaB.Repository.URL
+	commits, changelog := fetchGitHubContext(ctx, repoURL, versionA, versionB)
+
+	return &FetchResult{Diff: diff, CommitMessages: commits, Changelog: changelog, RepoURL: repoURL}, nil
+}
+
+func fetchNpmMeta(ctx context.Context, name string) (*npmPackageMeta, error) {
+	url := fmt.Sprintf("https://registry.npmjs.org/%s", name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch npm metadata for %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch npm metadata for %s: unexpected status %s", name, resp.Status)
+	}
+
+	var meta npmPackageMeta
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return nil, fmt.Errorf("decode npm metadata for %s: %w", name, err)
+	}
+	return &meta, nil
+}
@@ -0,0 +1,242 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ociManifest is the subset of an OCI/Docker image manifest this
+// collector needs: the list of layer blobs that make up the image's
+// filesystem.
+type ociManifest struct {
+	Layers []struct {
+		MediaType string `json:"mediaType"`
+		Digest    string `json:"digest"`
+	} `json:"layers"`
+}
+
+// FetchOCI pulls two container images from their registries, extracts
+// and merges each image's layers into a filesystem tree, and diffs the
+// two trees. Only anonymous-pull access is supported, which covers most
+// public images. Whiteout files (OCI's marker for "this path was
+// deleted in a later layer") aren't interpreted, so a file removed in a
+// later layer still appears in the merged tree — a known limitation
+// that can surface a removed file as unchanged rather than deleted.
+func FetchOCI(ctx context.Context, refA, refB string) (*FetchResult, error) {
+	dir, err := os.MkdirTemp("", "ucd-registry-oci-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	rootA, err := pullImage(ctx, filepath.Join(dir, "a"), refA)
+	if err != nil {
+		return nil, fmt.Errorf("pull %s: %w", refA, err)
+	}
+	rootB, err := pullImage(ctx, filepath.Join(dir, "b"), refB)
+	if err != nil {
+		return nil, fmt.Errorf("pull %s: %w", refB, err)
+	}
+
+	diff, err := diffTrees(rootA, rootB)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FetchResult{Diff: diff}, nil
+}
+
+// imageRefPattern splits "[host/]repo[:tag]" into its registry host (if
+// one is present — it must contain a dot to be recognized as a host
+// rather than the first path segment of a Docker Hub repo), repository
+// path, and tag.
+var imageRefPattern = regexp.MustCompile(`^(?:([^/]+\.[^/]+)/)?(.+?)(?::([^:/]+))?$`)
+
+type imageRef struct {
+	registry string
+	repo     string
+	tag      string
+}
+
+func parseImageRef(ref string) imageRef {
+	m := imageRefPattern.FindStringSubmatch(ref)
+	registry, repo, tag := m[1], m[2], m[3]
+
+	if registry == "" {
+		registry = "registry-1.docker.io"
+		if !strings.Contains(repo, "/") {
+			repo = "library/" + repo
+		}
+	}
+	if tag == "" {
+		tag = "latest"
+	}
+	return imageRef{registry: registry, repo: repo, tag: tag}
+}
+
+// pullImage fetches ref's manifest and layers and extracts them, in
+// order, into dir.
+func pullImage(ctx context.Context, dir, ref string) (string, error) {
+	r := parseImageRef(ref)
+
+	client := &ociClient{registry: r.registry}
+	manifest, err := client.manifest(ctx, r.repo, r.tag)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	for i, layer := range manifest.Layers {
+		blobPath, err := client.downloadBlob(ctx, dir, r.repo, layer.Digest, i)
+		if err != nil {
+			return "", err
+		}
+		if err := extractTarGz(blobPath, dir); err != nil {
+			return "", fmt.Errorf("extract layer %s: %w", layer.Digest, err)
+		}
+	}
+	return dir, nil
+}
+
+// ociClient is a minimal OCI Distribution API client: anonymous-token
+// auth, manifest lookup, and blob download.
+type ociClient struct {
+	registry string
+	token    string
+}
+
+func (c *ociClient) manifest(ctx context.Context, repo, tag string) (*ociManifest, error) {
+	reqURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", c.registry, repo, tag)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json")
+
+	resp, err := c.do(ctx, req, repo)
+	if err != nil {
+		return nil, fmt.Errorf("fetch manifest for %s:%s: %w", repo, tag, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch manifest for %s:%s: unexpected status %s", repo, tag, resp.Status)
+	}
+
+	var manifest ociManifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("decode manifest for %s:%s: %w", repo, tag, err)
+	}
+	return &manifest, nil
+}
+
+func (c *ociClient) downloadBlob(ctx context.Context, dir, repo, digest string, index int) (string, error) {
+	reqURL := fmt.Sprintf("https://%s/v2/%s/blobs/%s", c.registry, repo, digest)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do(ctx, req, repo)
+	if err != nil {
+		return "", fmt.Errorf("fetch blob %s: %w", digest, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch blob %s: unexpected status %s", digest, resp.Status)
+	}
+
+	dst := filepath.Join(dir, fmt.Sprintf("layer-%d.tar.gz", index))
+	f, err := os.Create(dst)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// do performs req, transparently handling the registry's anonymous
+// bearer-token challenge on a first 401 — the flow registries like
+// Docker Hub and ghcr.io use for public images.
+func (c *ociClient) do(ctx context.Context, req *http.Request, repo string) (*http.Response, error) {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized || c.token != "" {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	token, err := c.anonymousToken(ctx, resp.Header.Get("Www-Authenticate"), repo)
+	if err != nil {
+		return nil, fmt.Errorf("authenticate: %w", err)
+	}
+	c.token = token
+
+	req2 := req.Clone(ctx)
+	req2.Header.Set("Authorization", "Bearer "+c.token)
+	return http.DefaultClient.Do(req2)
+}
+
+var authChallengePattern = regexp.MustCompile(`Bearer realm="([^"]+)",service="([^"]+)"`)
+
+// anonymousToken requests a pull-scoped token from challenge's realm.
+func (c *ociClient) anonymousToken(ctx context.Context, challenge, repo string) (string, error) {
+	m := authChallengePattern.FindStringSubmatch(challenge)
+	if m == nil {
+		return "", fmt.Errorf("unrecognized auth challenge %q", challenge)
+	}
+	realm, service := m[1], m[2]
+
+	q := url.Values{
+		"service": {service},
+		"scope":   {fmt.Sprintf("repository:%s:pull", repo)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+q.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request: unexpected status %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
@@ -0,0 +1,114 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// pypiRelease is the subset of a PyPI release document this collector
+// needs: available distributions and declared project URLs.
+type pypiRelease struct {
+	Info struct {
+		ProjectURLs map[string]string `json:"project_urls"`
+		HomePage    string            `json:"home_page"`
+	} `json:"info"`
+	Urls []struct {
+		PackageType string `json:"packagetype"`
+		URL         string `json:"url"`
+	} `json:"urls"`
+}
+
+// FetchPyPI downloads the two published distributions for name, diffs
+// their extracted contents, and enriches the result with commit
+// messages and a changelog pulled from the package's declared source
+// repository, if PyPI knows about one.
+func FetchPyPI(ctx context.Context, name, versionA, versionB string) (*FetchResult, error) {
+	relA, err := fetchPyPIRelease(ctx, name, versionA)
+	if err != nil {
+		return nil, err
+	}
+	relB, err := fetchPyPIRelease(ctx, name, versionB)
+	if err != nil {
+		return nil, err
+	}
+
+	urlA, err := distURL(relA)
+	if err != nil {
+		return nil, fmt.Errorf("pypi package %s==%s: %w", name, versionA, err)
+	}
+	urlB, err := distURL(relB)
+	if err != nil {
+		return nil, fmt.Errorf("pypi package %s==%s: %w", name, versionB, err)
+	}
+
+	diff, err := diffArchives(ctx, urlA, extractorFor(urlA), urlB, extractorFor(urlB))
+	if err != nil {
+		return nil, err
+	}
+
+	repoURL := sourceRepoURL(relB)
+	commits, changelog := fetchGitHubContext(ctx, repoURL, versionA, versionB)
+
+	return &FetchResult{Diff: diff, CommitMessages: commits, Changelog: changelog, RepoURL: repoURL}, nil
+}
+
+func fetchPyPIRelease(ctx context.Context, name, version string) (*pypiRelease, error) {
+	url := fmt.Sprintf("https://pypi.org/pypi/%s/%s/json", name, version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch pypi metadata for %s==%s: %w", name, version, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch pypi metadata for %s==%s: unexpected status %s", name, version, resp.Status)
+	}
+
+	var rel pypiRelease
+	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+		return nil, fmt.Errorf("decode pypi metadata for %s==%s: %w", name, version, err)
+	}
+	return &rel, nil
+}
+
+// distURL prefers a release's sdist over a prebuilt wheel, since a
+// wheel's contents can diverge from the project's actual source layout.
+func distURL(rel *pypiRelease) (string, error) {
+	var fallback string
+	for _, u := range rel.Urls {
+		if u.PackageType == "sdist" {
+			return u.URL, nil
+		}
+		if fallback == "" {
+			fallback = u.URL
+		}
+	}
+	if fallback == "" {
+		return "", fmt.Errorf("no distributions available")
+	}
+	return fallback, nil
+}
+
+// sourceRepoURL picks the most likely source-repository link out of a
+// PyPI release's declared project URLs.
+func sourceRepoURL(rel *pypiRelease) string {
+	for key, url := range rel.Info.ProjectURLs {
+		lower := strings.ToLower(key)
+		if strings.Contains(lower, "source") || strings.Contains(lower, "repository") || strings.Contains(lower, "code") {
+			return url
+		}
+	}
+	for _, url := range rel.Info.ProjectURLs {
+		if strings.Contains(url, "github.com") {
+			return url
+		}
+	}
+	return rel.Info.HomePage
+}
@@ -0,0 +1,19 @@
+// Package registry collects AnalysisData-shaped inputs directly from
+// package ecosystem registries, rather than from a local git checkout:
+// npm, PyPI, the Go module proxy, and OCI image registries. Each
+// collector downloads both versions being compared and diffs their
+// extracted contents. The npm, PyPI, and Go module collectors also
+// best-effort enrich the result with commit messages and a changelog
+// pulled from the package's declared source repository, when one can be
+// found; the OCI collector returns the diff alone.
+package registry
+
+// FetchResult is an ecosystem collector's output: enough to build a
+// ucd.AnalysisData from, without this package needing to depend on the
+// ucd package itself.
+type FetchResult struct {
+	Diff           string
+	CommitMessages string
+	Changelog      string
+	RepoURL        string
+}
@@ -0,0 +1,42 @@
+package ucd
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+)
+
+// Runner executes an external command and captures its output. It exists
+// as a seam between a collector and the subprocess it invokes, so that
+// seam can be swapped for a sandbox (e.g. bubblewrap) when operating on an
+// untrusted repository, or for a fake in tests.
+//
+// Nothing in this codebase currently constructs or calls a Runner:
+// collectFromGit and getChangelogFromGit stopped shelling out after the
+// go-git migration, and the ecosystem collectors (npm, PyPI, etc.) reach
+// their registries over HTTP and go-git directly rather than through an
+// external binary. It's kept as a tested, ready seam for whichever future
+// collector is the first to actually need to invoke one.
+type Runner interface {
+	Run(ctx context.Context, dir, name string, args ...string) (stdout, stderr []byte, err error)
+}
+
+// execRunner runs commands directly via os/exec.
+type execRunner struct{}
+
+// NewExecRunner returns the default Runner, which shells out via os/exec.
+func NewExecRunner() Runner {
+	return execRunner{}
+}
+
+func (execRunner) Run(ctx context.Context, dir, name string, args ...string) ([]byte, []byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	return stdout.Bytes(), stderr.Bytes(), err
+}
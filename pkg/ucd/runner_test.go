@@ -0,0 +1,49 @@
+package ucd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// fakeRunner is a Runner test double that records each invocation and
+// returns canned output instead of spawning a process, so tests that need
+// a Runner seam never touch disk or a subprocess.
+type fakeRunner struct {
+	stdout, stderr []byte
+	err            error
+
+	calls []fakeRunnerCall
+}
+
+type fakeRunnerCall struct {
+	dir, name string
+	args      []string
+}
+
+var _ Runner = (*fakeRunner)(nil)
+
+func (f *fakeRunner) Run(_ context.Context, dir, name string, args ...string) ([]byte, []byte, error) {
+	f.calls = append(f.calls, fakeRunnerCall{dir: dir, name: name, args: args})
+	return f.stdout, f.stderr, f.err
+}
+
+func TestFakeRunnerRecordsCalls(t *testing.T) {
+	r := &fakeRunner{stdout: []byte("ok")}
+
+	stdout, _, err := r.Run(context.Background(), "/tmp/repo", "git", "log", "--oneline")
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !bytes.Equal(stdout, []byte("ok")) {
+		t.Errorf("stdout = %q, want %q", stdout, "ok")
+	}
+
+	if len(r.calls) != 1 {
+		t.Fatalf("calls = %+v, want exactly one recorded call", r.calls)
+	}
+	got := r.calls[0]
+	if got.dir != "/tmp/repo" || got.name != "git" || len(got.args) != 2 {
+		t.Errorf("calls[0] = %+v, want dir=/tmp/repo name=git with 2 args", got)
+	}
+}
@@ -0,0 +1,117 @@
+// Package sanitizer neutralizes prompt-injection attempts hiding in
+// untrusted text (a commit message, a source file, a changelog entry)
+// before that text is spliced into the analysis prompt. A hostile
+// upstream shouldn't be able to override ucd's instructions just by
+// writing "ignore previous instructions" into a commit message.
+package sanitizer
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// maxSectionBytes bounds a single sanitized section, well under the
+// prompt's global 2MB cap (see buildPrompt), so one oversized field can't
+// crowd out the others.
+const maxSectionBytes = 500_000
+
+// minBase64Len is the shortest base64-looking run treated as a possible
+// encoded payload rather than an incidental match.
+const minBase64Len = 200
+
+var (
+	// ignorePattern matches the most common instruction-override phrasing.
+	ignorePattern = regexp.MustCompile(`(?i)ignore (all |any )?(previous|prior|above|earlier) (instructions|prompts|rules)`)
+
+	// base64Pattern matches a long run of base64 alphabet characters,
+	// a weak but cheap signal for an encoded payload smuggled into text.
+	base64Pattern = regexp.MustCompile(fmt.Sprintf(`[A-Za-z0-9+/]{%d,}={0,2}`, minBase64Len))
+
+	// zeroWidthPattern matches zero-width characters sometimes used to
+	// hide text from human reviewers while an LLM still reads it.
+	zeroWidthPattern = regexp.MustCompile(`[\x{200B}-\x{200D}\x{FEFF}]`)
+
+	// bidiPattern matches Unicode bidirectional control characters
+	// (U+202A-U+202E), which can visually reorder text to disguise intent.
+	bidiPattern = regexp.MustCompile(`[\x{202A}-\x{202E}]`)
+
+	// fencePattern matches markdown code fences, which could otherwise be
+	// used to forge a fake end to the untrusted-input block.
+	fencePattern = regexp.MustCompile("```")
+
+	// headingPattern matches markdown headings, which could otherwise be
+	// used to forge a fake system-prompt section.
+	headingPattern = regexp.MustCompile(`(?m)^(\s*)(#{1,6}\s)`)
+
+	// delimiterPattern matches a literal UNTRUSTED_INPUT open or close tag
+	// inside untrusted text, which would otherwise let that text close its
+	// own wrapping block early (escaping into a position the model reads as
+	// trusted) or forge a second, fake block.
+	delimiterPattern = regexp.MustCompile(`(</?)(UNTRUSTED_INPUT\b[^>]*)(>)`)
+)
+
+// Flag records a detected injection trigger within a sanitized section.
+type Flag struct {
+	Section string
+	Reason  string
+}
+
+// Section sanitizes one untrusted text field before it's spliced into the
+// analysis prompt: it flags known injection triggers (stripping zero-width
+// and bidi control characters; instruction-override phrases and base64-like
+// blobs are flagged but left in the text), neutralizes markdown structure that could forge a fake delimiter or
+// instruction, enforces a per-section length cap, and wraps the result in
+// a clearly delimited UNTRUSTED_INPUT block.
+func Section(name, text string) (string, []Flag) {
+	var flags []Flag
+
+	if ignorePattern.MatchString(text) {
+		flags = append(flags, Flag{Section: name, Reason: `contains an instruction-override phrase ("ignore previous instructions")`})
+	}
+	if m := base64Pattern.FindString(text); m != "" {
+		flags = append(flags, Flag{Section: name, Reason: fmt.Sprintf("contains a %d-byte base64-like blob", len(m))})
+	}
+	if zeroWidthPattern.MatchString(text) {
+		flags = append(flags, Flag{Section: name, Reason: "contains zero-width characters"})
+		text = zeroWidthPattern.ReplaceAllString(text, "")
+	}
+	if bidiPattern.MatchString(text) {
+		flags = append(flags, Flag{Section: name, Reason: "contains bidirectional text-override control characters"})
+		text = bidiPattern.ReplaceAllString(text, "")
+	}
+	if delimiterPattern.MatchString(text) {
+		flags = append(flags, Flag{Section: name, Reason: "contains a forged UNTRUSTED_INPUT delimiter tag"})
+	}
+
+	text = neutralizeMarkdown(text)
+	text = truncate(text, maxSectionBytes)
+
+	return wrap(name, text), flags
+}
+
+// neutralizeMarkdown breaks code fences, demotes headings, and splits apart
+// any UNTRUSTED_INPUT delimiter tag already present in the text, so
+// untrusted text can't masquerade as a delimiter, close its wrapping block
+// early, or forge a new instruction section.
+func neutralizeMarkdown(s string) string {
+	s = fencePattern.ReplaceAllString(s, "' ' '")
+	s = headingPattern.ReplaceAllString(s, "$1\\$2")
+	s = delimiterPattern.ReplaceAllString(s, "$1 $2 $3")
+	return s
+}
+
+// truncate caps s at max bytes, the per-section length cap enforced
+// before the global prompt cap in buildPrompt.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "\n... [truncated, exceeded per-section cap]"
+}
+
+// wrap delimits text as an untrusted data block, paired with the
+// system-prompt reminder in promptTemplateStr that content inside these
+// blocks is data to analyze, not instructions to follow.
+func wrap(name, text string) string {
+	return fmt.Sprintf("<UNTRUSTED_INPUT name=%q>\n%s\n</UNTRUSTED_INPUT>", name, text)
+}
@@ -0,0 +1,235 @@
+// Package sarif serializes a ucd analysis Result as SARIF 2.1.0, the
+// format GitHub code scanning, GitLab, and most CI security dashboards
+// expect, so ucd's findings can flow into an existing scanner pipeline
+// instead of only being read by a human.
+package sarif
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tstromberg/ucd/pkg/ucd"
+)
+
+// schemaURI and version identify the SARIF spec version these types
+// implement.
+const (
+	schemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	version   = "2.1.0"
+)
+
+// toolName identifies ucd as the producing tool in the SARIF log.
+const toolName = "ucd"
+
+// Log is the top-level SARIF document.
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run is a single analysis run within a Log.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+// Tool describes the analysis tool that produced a Run.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver names the tool and the rules it can emit.
+type Driver struct {
+	Name  string `json:"name"`
+	Rules []Rule `json:"rules"`
+}
+
+// Rule describes one SARIF rule ID that can appear in Results.
+type Rule struct {
+	ID               string            `json:"id"`
+	ShortDescription MultiformatString `json:"shortDescription"`
+}
+
+// MultiformatString is SARIF's wrapper for a plain-text message.
+type MultiformatString struct {
+	Text string `json:"text"`
+}
+
+// Result is one SARIF finding.
+type Result struct {
+	RuleID    string            `json:"ruleId"`
+	Level     string            `json:"level"`
+	Message   MultiformatString `json:"message"`
+	Locations []ResultLocation  `json:"locations,omitempty"`
+}
+
+// ResultLocation wraps the physical file location of a Result.
+type ResultLocation struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation points at a file, and optionally a line within it.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+// ArtifactLocation identifies a file by URI, relative to the repo root.
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Region is a line range within an ArtifactLocation.
+type Region struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+// diffFileHeaderPattern matches unified diff file headers, to recover the
+// set of files a Result's diff touched.
+var diffFileHeaderPattern = regexp.MustCompile(`(?m)^diff --git a/\S+ b/(\S+)$`)
+
+// FromResult converts a ucd analysis Result into a SARIF Log. Each
+// Assessment becomes one SARIF result per non-zero risk dimension
+// (malware_risk, silent_patch), since an Assessment can carry both. The
+// Summary Assessment, if present, is included as an overview result with
+// no specific location.
+func FromResult(r *ucd.Result) *Log {
+	var results []Result
+
+	files := filesInDiff(r.Input.Diff)
+	for _, a := range r.UndocumentedChanges {
+		results = append(results, assessmentResults(a, files)...)
+	}
+	if r.Summary != nil {
+		results = append(results, assessmentResults(*r.Summary, nil)...)
+	}
+
+	return &Log{
+		Schema:  schemaURI,
+		Version: version,
+		Runs: []Run{
+			{
+				Tool:    Tool{Driver: Driver{Name: toolName, Rules: rulesFor(results)}},
+				Results: results,
+			},
+		},
+	}
+}
+
+// assessmentResults emits one SARIF Result per non-zero risk dimension on
+// a. files, if non-nil, is used to guess which diff file a's description
+// refers to.
+func assessmentResults(a ucd.Assessment, files []string) []Result {
+	var results []Result
+	if a.MalwareRisk > 0 {
+		results = append(results, Result{
+			RuleID:    ruleID("malware_risk", a.MalwareRisk),
+			Level:     sarifLevel(a.MalwareRisk),
+			Message:   MultiformatString{Text: firstNonEmpty(a.MalwareExplanation, a.Description)},
+			Locations: locationsFor(a, files),
+		})
+	}
+	if a.SilentPatch > 0 {
+		results = append(results, Result{
+			RuleID:    ruleID("silent_patch", a.SilentPatch),
+			Level:     sarifLevel(a.SilentPatch),
+			Message:   MultiformatString{Text: firstNonEmpty(a.SilentExplanation, a.Description)},
+			Locations: locationsFor(a, files),
+		})
+	}
+	return results
+}
+
+// ruleID builds a rule ID from a risk category and its severity bucket,
+// e.g. "ucd.malware_risk.high".
+func ruleID(category string, score int) string {
+	return fmt.Sprintf("ucd.%s.%s", category, severityBucket(score))
+}
+
+// severityBucket classifies a 0-10 score into a rule-ID suffix.
+func severityBucket(score int) string {
+	switch {
+	case score >= 7:
+		return "high"
+	case score >= 4:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// sarifLevel maps a 0-10 score to a SARIF result level.
+func sarifLevel(score int) string {
+	switch {
+	case score >= 7:
+		return "error"
+	case score >= 4:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// rulesFor derives the unique rule catalog a Log's Results reference.
+func rulesFor(results []Result) []Rule {
+	seen := make(map[string]bool)
+	var rules []Rule
+	for _, r := range results {
+		if seen[r.RuleID] {
+			continue
+		}
+		seen[r.RuleID] = true
+		rules = append(rules, Rule{ID: r.RuleID, ShortDescription: MultiformatString{Text: r.RuleID}})
+	}
+	return rules
+}
+
+// locationsFor returns a's SARIF location. When the model identified a
+// file (and, often, a line range) for a, that's used directly. Otherwise
+// it falls back to a best-effort guess: the first file from files that
+// appears as a substring of a's description, with no line range, since
+// there's nothing more precise to report.
+func locationsFor(a ucd.Assessment, files []string) []ResultLocation {
+	if a.File != "" {
+		loc := PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: a.File}}
+		if a.LineStart > 0 {
+			region := &Region{StartLine: a.LineStart}
+			if a.LineEnd > a.LineStart {
+				region.EndLine = a.LineEnd
+			}
+			loc.Region = region
+		}
+		return []ResultLocation{{PhysicalLocation: loc}}
+	}
+
+	for _, f := range files {
+		if f == "" {
+			continue
+		}
+		if strings.Contains(a.Description, f) {
+			return []ResultLocation{{PhysicalLocation: PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: f}}}}
+		}
+	}
+	return nil
+}
+
+// filesInDiff extracts the "b/" side file paths touched by a unified diff.
+func filesInDiff(diff string) []string {
+	matches := diffFileHeaderPattern.FindAllStringSubmatch(diff, -1)
+	files := make([]string, 0, len(matches))
+	for _, m := range matches {
+		files = append(files, m[1])
+	}
+	return files
+}
+
+// firstNonEmpty returns the first non-empty string, or "" if both are empty.
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
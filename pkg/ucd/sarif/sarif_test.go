@@ -0,0 +1,61 @@
+package sarif
+
+import (
+	"testing"
+
+	"github.com/tstromberg/ucd/pkg/ucd"
+)
+
+func TestFromResultUsesAssessmentLocationWhenPresent(t *testing.T) {
+	r := &ucd.Result{
+		Input: &ucd.AnalysisData{Diff: "diff --git a/main.go b/other.go\n"},
+		UndocumentedChanges: []ucd.Assessment{
+			{
+				Description: "adds a network call",
+				MalwareRisk: 6,
+				File:        "main.go",
+				LineStart:   10,
+				LineEnd:     14,
+			},
+		},
+	}
+
+	log := FromResult(r)
+	if len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("Results = %+v, want exactly one", log.Runs)
+	}
+
+	locs := log.Runs[0].Results[0].Locations
+	if len(locs) != 1 {
+		t.Fatalf("Locations = %+v, want exactly one", locs)
+	}
+	loc := locs[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "main.go" {
+		t.Errorf("URI = %q, want %q", loc.ArtifactLocation.URI, "main.go")
+	}
+	if loc.Region == nil || loc.Region.StartLine != 10 || loc.Region.EndLine != 14 {
+		t.Errorf("Region = %+v, want StartLine=10 EndLine=14", loc.Region)
+	}
+}
+
+func TestFromResultFallsBackToSubstringGuess(t *testing.T) {
+	r := &ucd.Result{
+		Input: &ucd.AnalysisData{Diff: "diff --git a/main.go b/main.go\n"},
+		UndocumentedChanges: []ucd.Assessment{
+			{Description: "main.go now exfiltrates credentials", MalwareRisk: 8},
+		},
+	}
+
+	log := FromResult(r)
+	locs := log.Runs[0].Results[0].Locations
+	if len(locs) != 1 {
+		t.Fatalf("Locations = %+v, want exactly one", locs)
+	}
+	loc := locs[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "main.go" {
+		t.Errorf("URI = %q, want the substring-matched file %q", loc.ArtifactLocation.URI, "main.go")
+	}
+	if loc.Region != nil {
+		t.Errorf("Region = %+v, want nil when falling back to the substring guess", loc.Region)
+	}
+}
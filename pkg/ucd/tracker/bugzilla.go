@@ -0,0 +1,84 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// BugzillaClient resolves bug IDs against a Bugzilla instance's REST API.
+type BugzillaClient struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewBugzillaClient creates a BugzillaClient against baseURL (e.g.
+// "https://bugzilla.mozilla.org"). apiKey may be empty for public bugs.
+func NewBugzillaClient(baseURL, apiKey string) *BugzillaClient {
+	return &BugzillaClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  http.DefaultClient,
+	}
+}
+
+// bugzillaResponse mirrors the subset of Bugzilla's REST "get bug" response
+// this package cares about.
+type bugzillaResponse struct {
+	Bugs []struct {
+		Summary  string   `json:"summary"`
+		Status   string   `json:"status"`
+		Keywords []string `json:"keywords"`
+	} `json:"bugs"`
+}
+
+// Resolve looks up id (a bare bug number) via Bugzilla's REST API.
+func (b *BugzillaClient) Resolve(ctx context.Context, id string) (*Issue, error) {
+	url := fmt.Sprintf("%s/rest/bug/%s", b.baseURL, id)
+	if b.apiKey != "" {
+		url += "?api_key=" + b.apiKey
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch bug %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch bug %s: unexpected status %s", id, resp.Status)
+	}
+
+	var parsed bugzillaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("decode bug %s: %w", id, err)
+	}
+	if len(parsed.Bugs) == 0 {
+		return nil, fmt.Errorf("bug %s not found", id)
+	}
+
+	bug := parsed.Bugs[0]
+	security := false
+	for _, k := range bug.Keywords {
+		if isSecurityLabel(k) {
+			security = true
+			break
+		}
+	}
+
+	return &Issue{
+		ID:           id,
+		Title:        bug.Summary,
+		State:        bug.Status,
+		Labels:       bug.Keywords,
+		SecurityFlag: security,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v62/github"
+)
+
+// GitHubClient resolves issue/PR numbers against a single GitHub repository.
+type GitHubClient struct {
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+// NewGitHubClient creates a GitHubClient for owner/repo. token may be empty
+// for unauthenticated (rate-limited) access.
+func NewGitHubClient(owner, repo, token string) *GitHubClient {
+	client := github.NewClient(nil)
+	if token != "" {
+		client = client.WithAuthToken(token)
+	}
+	return &GitHubClient{client: client, owner: owner, repo: repo}
+}
+
+// Resolve looks up id (a bare issue/PR number) via the GitHub Issues API,
+// which also serves pull requests.
+func (g *GitHubClient) Resolve(ctx context.Context, id string) (*Issue, error) {
+	num, err := strconv.Atoi(strings.TrimPrefix(id, "#"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub issue number %q: %w", id, err)
+	}
+
+	issue, _, err := g.client.Issues.Get(ctx, g.owner, g.repo, num)
+	if err != nil {
+		return nil, fmt.Errorf("get issue #%d: %w", num, err)
+	}
+
+	labels := make([]string, 0, len(issue.Labels))
+	security := false
+	for _, l := range issue.Labels {
+		name := l.GetName()
+		labels = append(labels, name)
+		if isSecurityLabel(name) {
+			security = true
+		}
+	}
+
+	return &Issue{
+		ID:           id,
+		Title:        issue.GetTitle(),
+		State:        issue.GetState(),
+		Labels:       labels,
+		SecurityFlag: security,
+	}, nil
+}
+
+// isSecurityLabel reports whether a tracker label (a GitHub label, a
+// Bugzilla keyword, or a generic HTTP tracker's label) indicates the issue
+// is security-sensitive.
+func isSecurityLabel(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.Contains(lower, "security") || strings.Contains(lower, "cve") || strings.Contains(lower, "vulnerability")
+}
